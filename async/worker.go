@@ -34,14 +34,20 @@ func (w *worker) start() {
 		return
 	}
 	w.status = workerStatusWorking
+	// added before the routine starts so that a Wait right after start can't miss it
+	w.wg.Add(1)
 	go w.doStart()
 
 }
 
 func (w *worker) doStart() {
-	w.wg.Add(1)
-	for w.status == workerStatusWorking {
-		task := <-w.taskQueue
+	defer w.wg.Done()
+	for w.isWorking() {
+		task, ok := <-w.taskQueue
+		if !ok {
+			// the queue is closed by Stop
+			return
+		}
 		if task == nil {
 			continue
 		}
@@ -50,7 +56,12 @@ func (w *worker) doStart() {
 		doTask(task)
 
 	}
-	w.wg.Done()
+}
+
+func (w *worker) isWorking() bool {
+	w.statusMu.Lock()
+	defer w.statusMu.Unlock()
+	return w.status == workerStatusWorking
 }
 
 func (w *worker) stop() {
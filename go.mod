@@ -3,13 +3,19 @@ module github.com/bryan-t/goeasy
 go 1.22.7
 
 retract v0.0.1-alpha.4
+
 retract v0.0.1-alpha.3
+
 retract v0.0.1-alpha.2
+
 retract v0.0.1-alpha.1
 
+require (
+	github.com/stretchr/testify v1.10.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.10.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,269 @@
+package obj
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// AccessOption customizes the behavior of Get and Set.
+type AccessOption func(*accessor)
+
+// WithAccessMapper converts values and map keys with the conversion rules of
+// mapper, e.g. the names of its registered enums. Defaults to a new mapper.
+func WithAccessMapper(mapper *Mapper) AccessOption {
+	return func(a *accessor) {
+		a.mapper = mapper
+	}
+}
+
+type accessor struct {
+	mapper *Mapper
+}
+
+func newAccessor(opts []AccessOption) *accessor {
+	a := &accessor{}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.mapper == nil {
+		a.mapper = NewMapper()
+	}
+	return a
+}
+
+// Get returns the value at path within v. Paths are made of field names
+// separated by dots, and indices or map keys in brackets, e.g.
+// Servers[2].Timeout or Labels[env]. Map keys containing dots or brackets are
+// quoted, e.g. Labels["app.kubernetes.io/name"].
+// Sample usage:
+//
+//	timeout, err := obj.Get(cfg, "Servers[2].Timeout")
+func Get(v any, path string, opts ...AccessOption) (any, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	a := newAccessor(opts)
+
+	current := reflect.ValueOf(v)
+	currentPath := ""
+	for _, segment := range segments {
+		current, err = indirectValue(current, currentPath)
+		if err != nil {
+			return nil, err
+		}
+		currentPath = joinSegmentPath(currentPath, segment)
+		current, err = a.getSegment(current, segment, currentPath, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !current.IsValid() || !current.CanInterface() {
+		return nil, nil
+	}
+	return current.Interface(), nil
+}
+
+// Set assigns value to the field at path within the value pointed to by v. See
+// [Get] for the syntax of paths. Nil pointers and maps on the way are allocated.
+// Strings are parsed when assigned to other types, e.g. "5s" to a
+// time.Duration, and other values are converted like [Mapper.Map] does. Use
+// [WithAccessMapper] to convert with the enums registered with a mapper.
+// Sample usage:
+//
+//	err := obj.Set(&cfg, "Servers[2].Timeout", "5s")
+//	...
+//	err = obj.Set(&user, "Status", "ACTIVE", obj.WithAccessMapper(mapper))
+func Set(v any, path string, value any, opts ...AccessOption) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return ErrNotAddresable
+	}
+	a := newAccessor(opts)
+	return a.setAt(target.Elem(), segments, "", func(dst reflect.Value, path string) error {
+		err := assignValue(a.mapper, dst, value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// setAt navigates v through segments and calls fn with the addressable value
+// at the end of the path.
+func (a *accessor) setAt(v reflect.Value, segments []pathSegment, path string, fn func(dst reflect.Value, path string) error) error {
+	if len(segments) == 0 {
+		return fn(v, path)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return a.setAt(v.Elem(), segments, path, fn)
+	case reflect.Interface:
+		if v.IsNil() {
+			return fmt.Errorf("%s: %w", path, ErrFieldNotFound)
+		}
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		err := a.setAt(elem, segments, path, fn)
+		if err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Map:
+		if segments[0].isField {
+			return fmt.Errorf("%s: %w", joinSegmentPath(path, segments[0]), ErrFieldNotFound)
+		}
+		path = joinSegmentPath(path, segments[0])
+		key, err := a.pathMapKey(v, segments[0], path)
+		if err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		err = a.setAt(elem, segments[1:], path, fn)
+		if err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	}
+
+	path = joinSegmentPath(path, segments[0])
+	child, err := a.getSegment(v, segments[0], path, true)
+	if err != nil {
+		return err
+	}
+	return a.setAt(child, segments[1:], path, fn)
+}
+
+// indirectValue dereferences pointers and interfaces.
+func indirectValue(v reflect.Value, path string) (reflect.Value, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("%s: nil value: %w", path, ErrFieldNotFound)
+		}
+		v = v.Elem()
+	}
+	return v, nil
+}
+
+// getSegment returns the field, element or map value of v referenced by
+// segment. Nil embedded pointers on the way to a promoted field are allocated
+// if allocate is true, otherwise the field is not found.
+func (a *accessor) getSegment(v reflect.Value, segment pathSegment, path string, allocate bool) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Struct:
+		if !segment.isField {
+			break
+		}
+		field, ok := v.Type().FieldByName(segment.field)
+		if !ok || !field.IsExported() {
+			break
+		}
+		fieldValue, ok := fieldByIndex(v, field.Index, allocate)
+		if !ok {
+			break
+		}
+		return fieldValue, nil
+	case reflect.Slice, reflect.Array:
+		if segment.isField {
+			break
+		}
+		i, err := strconv.Atoi(segment.key)
+		if err != nil || i < 0 {
+			return reflect.Value{}, fmt.Errorf("%w: %s", ErrInvalidPath, path)
+		}
+		if i >= v.Len() {
+			return reflect.Value{}, fmt.Errorf("%s: %w", path, ErrIndexOutOfRange)
+		}
+		return v.Index(i), nil
+	case reflect.Map:
+		if segment.isField {
+			break
+		}
+		key, err := a.pathMapKey(v, segment, path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val := v.MapIndex(key)
+		if !val.IsValid() {
+			break
+		}
+		return val, nil
+	}
+	return reflect.Value{}, fmt.Errorf("%s: %w", path, ErrFieldNotFound)
+}
+
+// fieldByIndex returns the nested field of v like reflect.Value.FieldByIndex,
+// but returns false instead of panicking on a nil embedded pointer. If
+// allocate is true, nil embedded pointers are allocated instead.
+func fieldByIndex(v reflect.Value, index []int, allocate bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !allocate || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func (a *accessor) pathMapKey(v reflect.Value, segment pathSegment, path string) (reflect.Value, error) {
+	key := reflect.New(v.Type().Key()).Elem()
+	err := assignValue(a.mapper, key, segment.key)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+func joinSegmentPath(path string, segment pathSegment) string {
+	if segment.isField {
+		return joinFieldPath(path, segment.field)
+	}
+	return path + segment.String()
+}
+
+// assignValue stores value in dst, parsing strings and mapping values of
+// other types with mapper, which defaults to a new mapper.
+func assignValue(mapper *Mapper, dst reflect.Value, value any) error {
+	if value == nil {
+		dst.SetZero()
+		return nil
+	}
+	if mapper == nil {
+		mapper = NewMapper()
+	}
+	src := reflect.ValueOf(value)
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	if handled, err := mapper.mapEnum(src, dst); handled {
+		return err
+	}
+	if s, ok := value.(string); ok && (dst.Kind() != reflect.String || isTextValue(dst.Type())) {
+		return setString(dst, s, DefaultEnvSeparator)
+	}
+	return mapper.mapValue(newMapState(), src, dst)
+}
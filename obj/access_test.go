@@ -0,0 +1,163 @@
+package obj
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAccessServer struct {
+	Host    string
+	Timeout time.Duration
+	Ports   []int
+}
+
+type testAccessEmbedded struct {
+	X int
+}
+
+type testAccessOuter struct {
+	*testAccessEmbedded
+	Y int
+}
+
+type testAccessConfig struct {
+	Servers []testAccessServer
+	Primary *testAccessServer
+	Labels  map[string]string
+	Weights map[int]float64
+	Nested  map[string]*testAccessServer
+	Extra   any
+}
+
+func TestGet(t *testing.T) {
+	cfg := testAccessConfig{
+		Servers: []testAccessServer{{Host: "a"}, {Host: "b", Timeout: time.Second, Ports: []int{80}}},
+		Labels:  map[string]string{"env": "prod", "app.io/name": "api"},
+		Weights: map[int]float64{1: 0.5},
+		Extra:   testAccessServer{Host: "c"},
+	}
+
+	tests := []struct {
+		path     string
+		expected any
+		err      error
+	}{
+		{path: "Servers[1].Timeout", expected: time.Second},
+		{path: "Servers[1].Ports[0]", expected: 80},
+		{path: "Labels[env]", expected: "prod"},
+		{path: `Labels["app.io/name"]`, expected: "api"},
+		{path: "Weights[1]", expected: 0.5},
+		{path: "Extra.Host", expected: "c"},
+		{path: "Servers[2].Timeout", err: ErrIndexOutOfRange},
+		{path: "Primary.Host", err: ErrFieldNotFound},
+		{path: "Labels[team]", err: ErrFieldNotFound},
+		{path: "Unknown", err: ErrFieldNotFound},
+		{path: "Servers.Host", err: ErrFieldNotFound},
+		{path: "Servers[1]..Host", err: ErrInvalidPath},
+		{path: "Servers[1", err: ErrInvalidPath},
+		{path: "Servers[x]", err: ErrInvalidPath},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			value, err := Get(&cfg, test.path)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err, "Get returned an error")
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	cfg := testAccessConfig{
+		Servers: []testAccessServer{{Host: "a"}, {Host: "b"}, {Host: "c"}},
+	}
+
+	assert.Nil(t, Set(&cfg, "Servers[2].Timeout", "5s"))
+	assert.Nil(t, Set(&cfg, "Servers[0].Ports", "80,443"))
+	assert.Nil(t, Set(&cfg, "Servers[1].Host", "x"))
+	assert.Nil(t, Set(&cfg, "Primary.Timeout", time.Minute))
+	assert.Nil(t, Set(&cfg, "Labels[env]", "prod"))
+	assert.Nil(t, Set(&cfg, "Weights[2]", "0.25"))
+	assert.Nil(t, Set(&cfg, "Nested[a].Host", "n"))
+	assert.Nil(t, Set(&cfg, "Nested[a].Ports", []int{1}))
+
+	assert.Equal(t, testAccessConfig{
+		Servers: []testAccessServer{
+			{Host: "a", Ports: []int{80, 443}},
+			{Host: "x"},
+			{Host: "c", Timeout: 5 * time.Second},
+		},
+		Primary: &testAccessServer{Timeout: time.Minute},
+		Labels:  map[string]string{"env": "prod"},
+		Weights: map[int]float64{2: 0.25},
+		Nested:  map[string]*testAccessServer{"a": {Host: "n", Ports: []int{1}}},
+	}, cfg)
+}
+
+func TestGetNilEmbeddedPointer(t *testing.T) {
+	_, err := Get(testAccessOuter{}, "X")
+	assert.ErrorIs(t, err, ErrFieldNotFound)
+
+	value, err := Get(testAccessOuter{testAccessEmbedded: &testAccessEmbedded{X: 1}}, "X")
+	assert.Nil(t, err, "Get returned an error")
+	assert.Equal(t, 1, value)
+}
+
+func TestSetNilEmbeddedPointer(t *testing.T) {
+	type Embedded struct {
+		X int
+	}
+	type Outer struct {
+		*Embedded
+		Y int
+	}
+	outer := Outer{}
+	assert.Nil(t, Set(&outer, "Y", 2))
+	assert.Nil(t, outer.Embedded, "embedded pointer allocated for another field")
+	assert.Nil(t, Set(&outer, "X", 3))
+	assert.Equal(t, Outer{Embedded: &Embedded{X: 3}, Y: 2}, outer)
+
+	// unexported embedded pointers can't be allocated
+	assert.ErrorIs(t, Set(&testAccessOuter{}, "X", 3), ErrFieldNotFound)
+}
+
+func TestSetWithMapper(t *testing.T) {
+	type Account struct {
+		Status   testEnumStatus
+		Statuses map[testEnumStatus]int
+	}
+	mapper := NewMapper()
+	err := RegisterEnum(mapper, testEnumStatusNames)
+	assert.Nil(t, err, "RegisterEnum returned an error")
+
+	account := Account{}
+	assert.Nil(t, Set(&account, "Status", "ACTIVE", WithAccessMapper(mapper)))
+	assert.Nil(t, Set(&account, "Statuses[SUSPENDED]", 2, WithAccessMapper(mapper)))
+	assert.Equal(t, Account{
+		Status:   testEnumStatusActive,
+		Statuses: map[testEnumStatus]int{testEnumStatusSuspended: 2},
+	}, account)
+
+	value, err := Get(account, "Statuses[SUSPENDED]", WithAccessMapper(mapper))
+	assert.Nil(t, err, "Get returned an error")
+	assert.Equal(t, 2, value)
+
+	assert.ErrorIs(t, Set(&account, "Status", "ACTIVE"), ErrInvalidValue, "enum isn't registered with the default mapper")
+}
+
+func TestSetErrors(t *testing.T) {
+	cfg := testAccessConfig{Servers: []testAccessServer{{}}}
+
+	assert.ErrorIs(t, Set(&cfg, "Servers[1].Host", "a"), ErrIndexOutOfRange)
+	assert.ErrorIs(t, Set(&cfg, "Servers[0].Timeout", "soon"), ErrInvalidValue)
+	assert.ErrorIs(t, Set(&cfg, "Servers[0].Host", 1), ErrMismatchType)
+	assert.ErrorIs(t, Set(&cfg, "Weights[x]", 1.0), ErrInvalidValue)
+	assert.ErrorIs(t, Set(&cfg, "Unknown", 1), ErrFieldNotFound)
+	assert.Equal(t, ErrNotAddresable, Set(cfg, "Servers[0].Host", "a"))
+}
@@ -0,0 +1,76 @@
+package obj
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ErrArrayLength returned when the length of the source doesn't fit the destination array.
+var ErrArrayLength error = fmt.Errorf("array length mismatch")
+
+// ArrayLengthPolicy indicates how arrays are mapped from arrays and slices of a different length.
+type ArrayLengthPolicy int
+
+const (
+	// ArrayErrorIfLonger fails if the source is longer and leaves the remaining
+	// elements of the destination untouched if it is shorter.
+	ArrayErrorIfLonger ArrayLengthPolicy = 0
+
+	// ArrayStrict fails unless the source has the same length as the destination.
+	ArrayStrict ArrayLengthPolicy = 1
+
+	// ArrayTruncate ignores the extra elements of a longer source and leaves the
+	// remaining elements of the destination untouched if it is shorter.
+	ArrayTruncate ArrayLengthPolicy = 2
+
+	// ArrayZeroFill fails if the source is longer and sets the remaining
+	// elements of the destination to their zero value if it is shorter.
+	ArrayZeroFill ArrayLengthPolicy = 3
+)
+
+// ArrayLengthError describes an array that couldn't be mapped due to the length of its source.
+// It matches ErrArrayLength, and ErrInsufficientCapacity if the source is longer.
+type ArrayLengthError struct {
+	// Path of the array, empty if it is the value passed to Map
+	Path string
+
+	// SourceLen is the length of the source
+	SourceLen int
+
+	// DestinationLen is the length of the destination array
+	DestinationLen int
+}
+
+func (e *ArrayLengthError) Error() string {
+	msg := fmt.Sprintf("%s: source has %d elements, destination has %d", ErrArrayLength, e.SourceLen, e.DestinationLen)
+	if e.Path != "" {
+		msg = e.Path + ": " + msg
+	}
+	return msg
+}
+
+func (e *ArrayLengthError) Is(target error) bool {
+	return target == ErrArrayLength || (target == ErrInsufficientCapacity && e.SourceLen > e.DestinationLen)
+}
+
+// arrayLength returns the number of elements to map from src to the dst
+// array according to the policy of the mapper. Remaining elements of dst are
+// set to their zero value if required.
+func (m *Mapper) arrayLength(state *mapState, src reflect.Value, dst reflect.Value) (int, error) {
+	srcLen, dstLen := src.Len(), dst.Len()
+	policy := m.cfg.options.ArrayLength
+	switch {
+	case srcLen == dstLen:
+		return srcLen, nil
+	case srcLen > dstLen && policy == ArrayTruncate:
+		return dstLen, nil
+	case srcLen < dstLen && policy != ArrayStrict:
+		if policy == ArrayZeroFill {
+			for i := srcLen; i < dstLen; i++ {
+				dst.Index(i).SetZero()
+			}
+		}
+		return srcLen, nil
+	}
+	return 0, &ArrayLengthError{Path: state.path(), SourceLen: srcLen, DestinationLen: dstLen}
+}
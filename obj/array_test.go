@@ -0,0 +1,89 @@
+package obj
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapArrayLengthPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   ArrayLengthPolicy
+		src      []int
+		expected [3]int
+		err      error
+	}{
+		{
+			name:     "Error if longer with shorter source",
+			policy:   ArrayErrorIfLonger,
+			src:      []int{1, 2},
+			expected: [3]int{1, 2, 9},
+		},
+		{
+			name:   "Error if longer with longer source",
+			policy: ArrayErrorIfLonger,
+			src:    []int{1, 2, 3, 4},
+			err:    ErrInsufficientCapacity,
+		},
+		{
+			name:     "Strict with equal length",
+			policy:   ArrayStrict,
+			src:      []int{1, 2, 3},
+			expected: [3]int{1, 2, 3},
+		},
+		{
+			name:   "Strict with shorter source",
+			policy: ArrayStrict,
+			src:    []int{1, 2},
+			err:    ErrArrayLength,
+		},
+		{
+			name:     "Truncate",
+			policy:   ArrayTruncate,
+			src:      []int{1, 2, 3, 4},
+			expected: [3]int{1, 2, 3},
+		},
+		{
+			name:     "Zero fill",
+			policy:   ArrayZeroFill,
+			src:      []int{1},
+			expected: [3]int{1, 0, 0},
+		},
+		{
+			name:   "Zero fill with longer source",
+			policy: ArrayZeroFill,
+			src:    []int{1, 2, 3, 4},
+			err:    ErrArrayLength,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapper := NewMapperWithOptions(MapperOptions{ArrayLength: test.policy})
+			dst := [3]int{9, 9, 9}
+			err := mapper.Map(test.src, &dst)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err, "Map returned an error")
+			assert.Equal(t, test.expected, dst)
+		})
+	}
+}
+
+func TestArrayLengthError(t *testing.T) {
+	type Point struct {
+		Coordinates []float64
+	}
+	type FixedPoint struct {
+		Coordinates [2]float64
+	}
+
+	dst := FixedPoint{}
+	err := NewMapperWithOptions(MapperOptions{ArrayLength: ArrayStrict}).Map(Point{Coordinates: []float64{1}}, &dst)
+
+	assert.EqualError(t, err, "Coordinates: array length mismatch: source has 1 elements, destination has 2")
+	assert.NotErrorIs(t, err, ErrInsufficientCapacity)
+}
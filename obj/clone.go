@@ -0,0 +1,83 @@
+package obj
+
+import (
+	"reflect"
+)
+
+// deepCopy returns an addressable copy of v. Pointers, slices, maps and
+// interfaces reachable through exported fields are copied as well, values
+// behind unexported fields are shared with v.
+func deepCopy(v reflect.Value) reflect.Value {
+	dst := reflect.New(v.Type()).Elem()
+	copyInto(dst, v, make(map[uintptr]reflect.Value))
+	return dst
+}
+
+func copyInto(dst reflect.Value, src reflect.Value, copied map[uintptr]reflect.Value) {
+	dst.Set(src)
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		if p, ok := copied[src.Pointer()]; ok && p.Type() == src.Type() {
+			dst.Set(p)
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		copied[src.Pointer()] = p
+		copyInto(p.Elem(), src.Elem(), copied)
+		dst.Set(p)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		copyInto(elem, src.Elem(), copied)
+		dst.Set(elem)
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if !src.Type().Field(i).IsExported() {
+				continue
+			}
+			copyInto(dst.Field(i), src.Field(i), copied)
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyInto(dst.Index(i), src.Index(i), copied)
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			copyInto(s.Index(i), src.Index(i), copied)
+		}
+		dst.Set(s)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		mp := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			val := reflect.New(src.Type().Elem()).Elem()
+			copyInto(val, iter.Value(), copied)
+			mp.SetMapIndex(iter.Key(), val)
+		}
+		dst.Set(mp)
+	}
+}
+
+// Clone returns a copy of src made by mapping it to a new value of the same
+// type with mapper, so configured field maps and redaction apply.
+// Sample usage:
+//
+//	logMapper := obj.NewMapperWithOptions(obj.MapperOptions{Redact: true})
+//	event, err := obj.Clone(logMapper, payment)
+func Clone[T any](mapper *Mapper, src T) (T, error) {
+	var dst T
+	err := mapper.Map(src, &dst)
+	return dst, err
+}
@@ -0,0 +1,303 @@
+package obj
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CollectionStrategy indicates how slices and maps are mapped onto a destination that already has elements.
+type CollectionStrategy int
+
+const (
+	// CollectionAppend appends slice elements to the destination and sets map
+	// entries over the existing ones.
+	CollectionAppend CollectionStrategy = 0
+
+	// CollectionReplace discards the elements of the destination.
+	CollectionReplace CollectionStrategy = 1
+
+	// CollectionReconcile matches the elements of the source with those of the
+	// destination. Matched elements are mapped in place, new ones are added and
+	// missing ones are removed unless [CollectionConfig.KeepMissing] is set.
+	// Slice elements are matched by [CollectionConfig.Key], map entries by their key.
+	CollectionReconcile CollectionStrategy = 2
+)
+
+// CollectionConfig contains configuration on how to map a slice or a map.
+type CollectionConfig struct {
+	Strategy CollectionStrategy
+
+	// Key is the name of the field identifying the elements, e.g. ID. It is
+	// required to reconcile slices. It also allows slices to be mapped to maps
+	// keyed by the field and maps to be mapped to slices.
+	Key string
+
+	// KeepMissing keeps the destination elements that are not in the source when reconciling.
+	KeepMissing bool
+}
+
+// ConfigureCollection sets how slices or maps of type T are mapped when they
+// are the destination. Use [FieldMapConfig.Collection] to configure a single field.
+// Sample usage:
+//
+//	err := obj.ConfigureCollection[[]LineItem](mapper, obj.CollectionConfig{
+//		Strategy: obj.CollectionReconcile,
+//		Key:      "ID",
+//	})
+func ConfigureCollection[T any](mapper *Mapper, cfg CollectionConfig) error {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Map) {
+		return fmt.Errorf("T must be a slice or a map")
+	}
+	err := cfg.validate(t)
+	if err != nil {
+		return err
+	}
+	mapper.cfg.collections[t] = &cfg
+	return nil
+}
+
+// validate checks that cfg can be used for destinations of type t.
+func (cfg *CollectionConfig) validate(t reflect.Type) error {
+	t = indirectType(t)
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Map {
+		return fmt.Errorf("collection config used for %s which is not a slice or a map", t)
+	}
+	if cfg.Strategy < CollectionAppend || cfg.Strategy > CollectionReconcile {
+		return fmt.Errorf("unknown collection strategy %d", cfg.Strategy)
+	}
+	if t.Kind() != reflect.Slice {
+		return nil
+	}
+	if cfg.Key == "" {
+		if cfg.Strategy == CollectionReconcile {
+			return fmt.Errorf("key must be provided to reconcile %s", t)
+		}
+		return nil
+	}
+	elemType := indirectType(t.Elem())
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("elements of %s must be structs to have key %s", t, cfg.Key)
+	}
+	keyField, ok := elemType.FieldByName(cfg.Key)
+	if !ok {
+		return fmt.Errorf("%s: %w", cfg.Key, ErrFieldNotFound)
+	}
+	if !keyField.Type.Comparable() {
+		return fmt.Errorf("key %s of %s is not comparable", cfg.Key, elemType)
+	}
+	return nil
+}
+
+// collectionConfig returns the config of the field being mapped or of the destination type.
+func (m *Mapper) collectionConfig(state *mapState, t reflect.Type) *CollectionConfig {
+	if state.collection != nil {
+		return state.collection
+	}
+	if cfg, ok := m.collectionOf(t); ok {
+		return cfg
+	}
+	return &CollectionConfig{}
+}
+
+// collectionEntry is an element of the source of a slice or map.
+type collectionEntry struct {
+	state *mapState
+	key   reflect.Value // invalid if the source is not a map
+	value reflect.Value
+}
+
+// collectionEntries returns the elements of src in order. Map entries are sorted by key.
+func collectionEntries(state *mapState, src reflect.Value) []collectionEntry {
+	var entries []collectionEntry
+	if src.Kind() == reflect.Map {
+		keys := src.MapKeys()
+		sortValues(keys)
+		for _, key := range keys {
+			entryState, ok := state.key(key)
+			if !ok {
+				continue
+			}
+			entries = append(entries, collectionEntry{state: entryState, key: key, value: src.MapIndex(key)})
+		}
+		return entries
+	}
+	for i := 0; i < src.Len(); i++ {
+		entryState, ok := state.index(i)
+		if !ok {
+			continue
+		}
+		entries = append(entries, collectionEntry{state: entryState, value: src.Index(i)})
+	}
+	return entries
+}
+
+// keyField returns the value of the field or getter named name of the struct v points to.
+func keyField(v reflect.Value, name string) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	if field := v.FieldByName(name); field.IsValid() {
+		return field, true
+	}
+	getterMethod := v.MethodByName("Get" + name)
+	if getterMethod.IsValid() && getterMethod.Type().NumIn() == 0 && getterMethod.Type().NumOut() == 1 {
+		return getterMethod.Call(nil)[0], true
+	}
+	return reflect.Value{}, false
+}
+
+func (m *Mapper) mapSlice(state *mapState, src reflect.Value, dst reflect.Value) error {
+	cfg := m.collectionConfig(state, dst.Type())
+	switch src.Type().Kind() {
+	case reflect.Array, reflect.Slice:
+	case reflect.Map:
+		if cfg.Key == "" {
+			return ErrMismatchType
+		}
+	default:
+		return ErrMismatchType
+	}
+
+	entries := collectionEntries(state, src)
+	elemType := dst.Type().Elem()
+	var result reflect.Value
+	var existing map[any]int
+	var matched []bool
+	switch cfg.Strategy {
+	case CollectionReplace:
+		result = reflect.MakeSlice(dst.Type(), 0, len(entries))
+	case CollectionReconcile:
+		result = reflect.MakeSlice(dst.Type(), 0, len(entries))
+		keyType := indirectType(elemType)
+		keyStructField, _ := keyType.FieldByName(cfg.Key)
+		existing = make(map[any]int, dst.Len())
+		matched = make([]bool, dst.Len())
+		for i := 0; i < dst.Len(); i++ {
+			if key, ok := keyField(dst.Index(i), cfg.Key); ok {
+				existing[key.Interface()] = i
+			}
+		}
+		for _, entry := range entries {
+			if err := state.err(); err != nil {
+				return err
+			}
+			key, err := m.mapKey(state, entry, cfg.Key, keyStructField.Type)
+			if err != nil {
+				return err
+			}
+			elem := reflect.New(elemType).Elem()
+			if i, ok := existing[key.Interface()]; ok && !matched[i] {
+				matched[i] = true
+				elem.Set(dst.Index(i))
+			}
+			err = m.mapValue(entry.state, entry.value, elem)
+			if err != nil {
+				return err
+			}
+			result = reflect.Append(result, elem)
+		}
+		if cfg.KeepMissing {
+			for i := 0; i < dst.Len(); i++ {
+				if !matched[i] {
+					result = reflect.Append(result, dst.Index(i))
+				}
+			}
+		}
+		dst.Set(result)
+		return nil
+	default:
+		result = dst
+	}
+
+	for _, entry := range entries {
+		if err := state.err(); err != nil {
+			return err
+		}
+		elem := reflect.New(elemType).Elem()
+		err := m.mapValue(entry.state, entry.value, elem)
+		if err != nil {
+			return err
+		}
+		result = reflect.Append(result, elem)
+	}
+	dst.Set(result)
+	return nil
+}
+
+func (m *Mapper) mapMap(state *mapState, src reflect.Value, dst reflect.Value) error {
+	cfg := m.collectionConfig(state, dst.Type())
+	switch src.Type().Kind() {
+	case reflect.Map:
+	case reflect.Array, reflect.Slice:
+		if cfg.Key == "" {
+			return ErrMismatchType
+		}
+	default:
+		return ErrMismatchType
+	}
+
+	if dst.IsNil() || cfg.Strategy == CollectionReplace {
+		dst.Set(reflect.MakeMap(dst.Type()))
+	}
+	existing := make(map[any]reflect.Value)
+	if cfg.Strategy == CollectionReconcile {
+		iter := dst.MapRange()
+		for iter.Next() {
+			existing[iter.Key().Interface()] = iter.Value()
+		}
+	}
+	mapped := make(map[any]bool)
+	for _, entry := range collectionEntries(state, src) {
+		if err := state.err(); err != nil {
+			return err
+		}
+		dstKey, err := m.mapKey(state, entry, cfg.Key, dst.Type().Key())
+		if err != nil {
+			return err
+		}
+		if mapped[dstKey.Interface()] {
+			switch m.cfg.options.KeyCollision {
+			case KeyCollisionFirstWins:
+				continue
+			case KeyCollisionLastWins:
+			default:
+				srcKey := entry.key
+				if !srcKey.IsValid() {
+					srcKey, _ = keyField(entry.value, cfg.Key)
+				}
+				return &MapKeyError{
+					Path: state.path(),
+					Key:  interfaceOrNil(srcKey),
+					Err:  fmt.Errorf("%w: %#v is mapped from another key", ErrKeyCollision, dstKey.Interface()),
+				}
+			}
+		}
+		mapped[dstKey.Interface()] = true
+		dstVal := reflect.New(dst.Type().Elem()).Elem()
+		if value, ok := existing[dstKey.Interface()]; ok {
+			dstVal.Set(value)
+		}
+		err = m.mapValue(entry.state, entry.value, dstVal)
+		if err != nil {
+			return err
+		}
+		dst.SetMapIndex(dstKey, dstVal)
+	}
+
+	if cfg.Strategy == CollectionReconcile && !cfg.KeepMissing {
+		for _, key := range dst.MapKeys() {
+			if !mapped[key.Interface()] {
+				dst.SetMapIndex(key, reflect.Value{})
+			}
+		}
+	}
+	return nil
+}
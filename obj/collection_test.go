@@ -0,0 +1,176 @@
+package obj
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLineItemDTO struct {
+	ID  int
+	Qty int
+}
+
+type testLineItem struct {
+	ID   int
+	Qty  int
+	Note string
+}
+
+type testOrderDTO struct {
+	Items []testLineItemDTO
+}
+
+type testOrder struct {
+	Items []testLineItem
+}
+
+func TestMapCollectionStrategies(t *testing.T) {
+	existing := func() testOrder {
+		return testOrder{Items: []testLineItem{{1, 1, "first"}, {2, 2, "second"}}}
+	}
+	src := testOrderDTO{Items: []testLineItemDTO{{2, 5}, {3, 1}}}
+	tests := []struct {
+		name     string
+		cfg      *CollectionConfig
+		expected []testLineItem
+	}{
+		{
+			name:     "Append",
+			expected: []testLineItem{{1, 1, "first"}, {2, 2, "second"}, {2, 5, ""}, {3, 1, ""}},
+		},
+		{
+			name:     "Replace",
+			cfg:      &CollectionConfig{Strategy: CollectionReplace},
+			expected: []testLineItem{{2, 5, ""}, {3, 1, ""}},
+		},
+		{
+			name:     "Reconcile",
+			cfg:      &CollectionConfig{Strategy: CollectionReconcile, Key: "ID"},
+			expected: []testLineItem{{2, 5, "second"}, {3, 1, ""}},
+		},
+		{
+			name:     "Reconcile and keep missing",
+			cfg:      &CollectionConfig{Strategy: CollectionReconcile, Key: "ID", KeepMissing: true},
+			expected: []testLineItem{{2, 5, "second"}, {3, 1, ""}, {1, 1, "first"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name+" by type", func(t *testing.T) {
+			mapper := NewMapper()
+			if test.cfg != nil {
+				err := ConfigureCollection[[]testLineItem](mapper, *test.cfg)
+				assert.Nil(t, err, "ConfigureCollection returned an error")
+			}
+			dst := existing()
+			err := mapper.Map(src, &dst)
+			assert.Nil(t, err, "Map returned an error")
+			assert.Equal(t, test.expected, dst.Items)
+		})
+		t.Run(test.name+" by field", func(t *testing.T) {
+			mapper := NewMapper()
+			if test.cfg != nil {
+				err := ConfigureFieldMaps[testOrderDTO, testOrder](mapper, FieldMapConfig{
+					Destination: "Items",
+					Collection:  test.cfg,
+				})
+				assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+			}
+			dst := existing()
+			err := mapper.Map(src, &dst)
+			assert.Nil(t, err, "Map returned an error")
+			assert.Equal(t, test.expected, dst.Items)
+		})
+	}
+}
+
+func TestMapReconcilePointers(t *testing.T) {
+	mapper := NewMapper()
+	err := ConfigureCollection[[]*testLineItem](mapper, CollectionConfig{Strategy: CollectionReconcile, Key: "ID"})
+	assert.Nil(t, err, "ConfigureCollection returned an error")
+
+	item := &testLineItem{ID: 1, Qty: 1, Note: "first"}
+	dst := []*testLineItem{item}
+	err = mapper.Map([]testLineItemDTO{{1, 3}}, &dst)
+
+	assert.Nil(t, err, "Map returned an error")
+	assert.Same(t, item, dst[0])
+	assert.Equal(t, testLineItem{1, 3, "first"}, *item)
+}
+
+func TestMapReconcileMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      CollectionConfig
+		expected map[string]testLineItem
+	}{
+		{
+			name:     "Remove missing",
+			cfg:      CollectionConfig{Strategy: CollectionReconcile},
+			expected: map[string]testLineItem{"b": {2, 5, "second"}, "c": {3, 1, ""}},
+		},
+		{
+			name:     "Keep missing",
+			cfg:      CollectionConfig{Strategy: CollectionReconcile, KeepMissing: true},
+			expected: map[string]testLineItem{"a": {1, 1, "first"}, "b": {2, 5, "second"}, "c": {3, 1, ""}},
+		},
+		{
+			name:     "Replace",
+			cfg:      CollectionConfig{Strategy: CollectionReplace},
+			expected: map[string]testLineItem{"b": {2, 5, ""}, "c": {3, 1, ""}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapper := NewMapper()
+			err := ConfigureCollection[map[string]testLineItem](mapper, test.cfg)
+			assert.Nil(t, err, "ConfigureCollection returned an error")
+
+			dst := map[string]testLineItem{"a": {1, 1, "first"}, "b": {2, 2, "second"}}
+			err = mapper.Map(map[string]testLineItemDTO{"b": {2, 5}, "c": {3, 1}}, &dst)
+			assert.Nil(t, err, "Map returned an error")
+			assert.Equal(t, test.expected, dst)
+		})
+	}
+}
+
+func TestMapSliceMapConversion(t *testing.T) {
+	mapper := NewMapper()
+	err := ConfigureCollection[map[int]testLineItem](mapper, CollectionConfig{Key: "ID"})
+	assert.Nil(t, err, "ConfigureCollection returned an error")
+	err = ConfigureCollection[[]testLineItemDTO](mapper, CollectionConfig{Key: "ID"})
+	assert.Nil(t, err, "ConfigureCollection returned an error")
+
+	byID := map[int]testLineItem{}
+	err = mapper.Map([]testLineItemDTO{{2, 5}, {1, 3}}, &byID)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, map[int]testLineItem{1: {ID: 1, Qty: 3}, 2: {ID: 2, Qty: 5}}, byID)
+
+	var items []testLineItemDTO
+	err = mapper.Map(byID, &items)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, []testLineItemDTO{{1, 3}, {2, 5}}, items)
+
+	err = NewMapper().Map(byID, &items)
+	assert.Equal(t, ErrMismatchType, err)
+}
+
+func TestConfigureCollectionErrors(t *testing.T) {
+	mapper := NewMapper()
+	err := ConfigureCollection[testLineItem](mapper, CollectionConfig{})
+	assert.EqualError(t, err, "T must be a slice or a map")
+
+	err = ConfigureCollection[[]testLineItem](mapper, CollectionConfig{Strategy: CollectionReconcile})
+	assert.EqualError(t, err, "key must be provided to reconcile []obj.testLineItem")
+
+	err = ConfigureCollection[[]testLineItem](mapper, CollectionConfig{Key: "Code"})
+	assert.ErrorIs(t, err, ErrFieldNotFound)
+
+	err = ConfigureFieldMaps[testOrderDTO, testOrder](mapper, FieldMapConfig{
+		Destination: "Items",
+		Collection:  &CollectionConfig{Strategy: CollectionStrategy(9)},
+	})
+	assert.EqualError(t, err, "Items: unknown collection strategy 9")
+}
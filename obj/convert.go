@@ -0,0 +1,98 @@
+package obj
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidValue returned when a string can't be parsed into the destination type.
+var ErrInvalidValue error = fmt.Errorf("invalid value")
+
+// ErrUnsupportedType returned when a value of the destination type can't be created from a string.
+var ErrUnsupportedType error = fmt.Errorf("unsupported type")
+
+var durationType = reflect.TypeOf(time.Duration(0))
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// setString parses s according to the type of dst and stores the result in dst.
+// Slices are populated by splitting s with sep.
+func setString(dst reflect.Value, s string, sep string) error {
+	if dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshalerType) {
+		err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		if err != nil {
+			return fmt.Errorf("%w: %q as %s: %v", ErrInvalidValue, s, dst.Type(), err)
+		}
+		return nil
+	}
+	if dst.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("%w: %q as %s", ErrInvalidValue, s, dst.Type())
+		}
+		dst.SetInt(int64(d))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%w: %q as %s", ErrInvalidValue, s, dst.Type())
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 0, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%w: %q as %s", ErrInvalidValue, s, dst.Type())
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 0, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%w: %q as %s", ErrInvalidValue, s, dst.Type())
+		}
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%w: %q as %s", ErrInvalidValue, s, dst.Type())
+		}
+		dst.SetFloat(f)
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Pointer:
+		newVal := reflect.New(dst.Type().Elem())
+		err := setString(newVal.Elem(), s, sep)
+		if err != nil {
+			return err
+		}
+		dst.Set(newVal)
+	case reflect.Slice:
+		if s == "" {
+			dst.Set(reflect.MakeSlice(dst.Type(), 0, 0))
+			return nil
+		}
+		parts := strings.Split(s, sep)
+		slice := reflect.MakeSlice(dst.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			err := setString(slice.Index(i), strings.TrimSpace(part), sep)
+			if err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, dst.Type())
+	}
+	return nil
+}
+
+// isTextValue reports whether values of t are parsed as a whole from text
+// instead of being traversed.
+func isTextValue(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
@@ -0,0 +1,138 @@
+package obj
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// DecodeError describes a value that couldn't be decoded by [Mapper.Decode].
+type DecodeError struct {
+	// Path of the value, e.g. Servers[2].Timeout
+	Path string
+
+	// Err is the reason why the value couldn't be decoded
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Decode is like Map but also decodes loosely typed values like those of
+// decoded JSON or YAML, e.g. map[string]any and []any, into typed values:
+//   - maps with string keys are decoded into structs, matching keys to the
+//     names of the fields or their JSON names, regardless of case
+//   - numbers are converted to numbers of other types if they can be
+//     represented exactly, e.g. float64(2) to int but not 2.5
+//   - strings are parsed into durations, and into times and other
+//     encoding.TextUnmarshaler implementations
+//   - missing and zero fields are set from their default tags with
+//     [MapperOptions.Defaults]
+//
+// Errors are wrapped in a [DecodeError] with the path of the offending value.
+// Sample usage:
+//
+//	var payload map[string]any
+//	err := json.Unmarshal(body, &payload)
+//	...
+//	order := Order{}
+//	err = mapper.Decode(payload, &order)
+func (m *Mapper) Decode(src any, dst any) error {
+	srcValue := reflect.ValueOf(src)
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Type().Kind() == reflect.Pointer {
+		dstValue = dstValue.Elem()
+	}
+	if !dstValue.CanAddr() {
+		return ErrNotAddresable
+	}
+	state := newMapState()
+	state.weak = true
+	return m.mapValue(state, srcValue, dstValue)
+}
+
+// mapWeak decodes src into dst if it requires a conversion that only Decode
+// does. It returns false if src is to be mapped like in Map.
+func (m *Mapper) mapWeak(state *mapState, src reflect.Value, dst reflect.Value) (bool, error) {
+	switch {
+	case dst.Kind() == reflect.Struct && src.Kind() == reflect.Map && src.Type().Key().Kind() == reflect.String:
+		return true, m.decodeStruct(state, src, dst)
+	case isNumberKind(src.Kind()) && isNumberKind(dst.Kind()) && src.Kind() != dst.Kind():
+		return true, decodeError(state, convertNumber(src, dst))
+	case src.Kind() == reflect.String && dst.Type() == durationType:
+		return true, decodeError(state, setString(dst, src.String(), DefaultEnvSeparator))
+	}
+	return false, nil
+}
+
+// decodeStruct decodes a map with string keys into the fields of the dst struct.
+func (m *Mapper) decodeStruct(state *mapState, src reflect.Value, dst reflect.Value) error {
+	folded := make(map[string]reflect.Value, src.Len())
+	iter := src.MapRange()
+	for iter.Next() {
+		folded[strings.ToLower(iter.Key().String())] = iter.Key()
+	}
+
+	for i := 0; i < dst.NumField(); i++ {
+		structField := dst.Type().Field(i)
+		dstField := dst.Field(i)
+		jsonName, hasJSONName := jsonFieldName(structField)
+		if structField.Anonymous && structField.Tag.Get("json") == "" && indirectType(structField.Type).Kind() == reflect.Struct {
+			// fields of embedded structs are decoded from the same map
+			if dstField.Kind() == reflect.Pointer && dstField.IsNil() {
+				if !dstField.CanSet() {
+					continue
+				}
+				dstField.Set(reflect.New(structField.Type.Elem()))
+			}
+			err := m.decodeStruct(state, src, reflect.Indirect(dstField))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if !structField.IsExported() || !hasJSONName {
+			continue
+		}
+		fieldState, ok := state.field(structField)
+		if !ok {
+			continue
+		}
+
+		srcField := reflect.Value{}
+		for _, name := range []string{jsonName, structField.Name} {
+			if srcField = src.MapIndex(reflect.ValueOf(name).Convert(src.Type().Key())); srcField.IsValid() {
+				break
+			}
+			if key, ok := folded[strings.ToLower(name)]; ok {
+				srcField = src.MapIndex(key)
+				break
+			}
+		}
+		err := m.mapValue(fieldState, srcField, dstField)
+		if err != nil {
+			return decodeError(fieldState, err)
+		}
+		err = m.applyFieldDefaults(structField, dstField, srcField.IsValid())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeError wraps err in a DecodeError with the path of state unless it already has a path.
+func decodeError(state *mapState, err error) error {
+	var decodeErr *DecodeError
+	var keyErr *MapKeyError
+	var lengthErr *ArrayLengthError
+	if err == nil || state.parent == nil || errors.As(err, &decodeErr) || errors.As(err, &keyErr) || errors.As(err, &lengthErr) {
+		return err
+	}
+	return &DecodeError{Path: state.path(), Err: err}
+}
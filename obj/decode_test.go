@@ -0,0 +1,118 @@
+package obj
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDecodeMeta struct {
+	Version int `json:"version"`
+}
+
+type testDecodeServer struct {
+	Host    string        `json:"host"`
+	Port    uint16        `json:"port"`
+	Timeout time.Duration `json:"timeout" default:"5s"`
+}
+
+type testDecodeConfig struct {
+	testDecodeMeta
+	Name      string
+	CreatedAt time.Time           `json:"created_at"`
+	Ratio     float32             `json:"ratio"`
+	Servers   []testDecodeServer  `json:"servers"`
+	Primary   *testDecodeServer   `json:"primary"`
+	Labels    map[string]int      `json:"labels"`
+	Extra     any                 `json:"extra"`
+	ByName    map[string]struct{} `json:"-"`
+}
+
+func TestDecode(t *testing.T) {
+	payload := `{
+		"version": 2,
+		"NAME": "prod",
+		"created_at": "2024-05-01T10:00:00Z",
+		"ratio": 0.5,
+		"servers": [{"host": "a", "port": 8080, "timeout": "1m"}, {"host": "b", "port": 8081}],
+		"primary": {"Host": "a", "Port": 8080},
+		"labels": {"replicas": 3},
+		"extra": {"any": [1, "two"]},
+		"ByName": {"x": {}}
+	}`
+	var src map[string]any
+	err := json.Unmarshal([]byte(payload), &src)
+	assert.Nil(t, err, "Unmarshal returned an error")
+
+	dst := testDecodeConfig{}
+	err = NewMapperWithOptions(MapperOptions{Defaults: true}).Decode(src, &dst)
+
+	assert.Nil(t, err, "Decode returned an error")
+	assert.Equal(t, testDecodeConfig{
+		testDecodeMeta: testDecodeMeta{Version: 2},
+		Name:           "prod",
+		CreatedAt:      time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		Ratio:          0.5,
+		Servers: []testDecodeServer{
+			{Host: "a", Port: 8080, Timeout: time.Minute},
+			{Host: "b", Port: 8081, Timeout: 5 * time.Second},
+		},
+		Primary: &testDecodeServer{Host: "a", Port: 8080, Timeout: 5 * time.Second},
+		Labels:  map[string]int{"replicas": 3},
+		Extra:   map[string]any{"any": []any{float64(1), "two"}},
+	}, dst)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  map[string]any
+		path string
+		err  error
+	}{
+		{
+			name: "Fraction",
+			src:  map[string]any{"servers": []any{map[string]any{"port": 80.5}}},
+			path: "Servers[0].Port",
+			err:  ErrInvalidValue,
+		},
+		{
+			name: "Overflow",
+			src:  map[string]any{"servers": []any{map[string]any{"port": float64(70000)}}},
+			path: "Servers[0].Port",
+			err:  ErrInvalidValue,
+		},
+		{
+			name: "Wrong type",
+			src:  map[string]any{"primary": map[string]any{"host": true}},
+			path: "Primary.Host",
+			err:  ErrMismatchType,
+		},
+		{
+			name: "Invalid time",
+			src:  map[string]any{"created_at": "yesterday"},
+			path: "CreatedAt",
+			err:  ErrInvalidValue,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dst := testDecodeConfig{}
+			err := NewMapper().Decode(test.src, &dst)
+
+			var decodeErr *DecodeError
+			assert.ErrorAs(t, err, &decodeErr)
+			assert.Equal(t, test.path, decodeErr.Path)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestMapIsStrict(t *testing.T) {
+	dst := testDecodeServer{}
+	err := NewMapper().Map(map[string]any{"host": "a"}, &dst)
+	assert.Equal(t, ErrMismatchType, err)
+}
@@ -0,0 +1,113 @@
+package obj
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplyDefaults sets the fields of the struct pointed to by v that have a zero
+// value to the value of their default tag, e.g. `default:"5s"`. Tag values are
+// parsed according to the type of the field, slices are separated by commas.
+// Nested structs, pointers to structs and their slices are handled recursively.
+// A mapper only applies default tags with [MapperOptions.Defaults].
+// Sample usage:
+//
+//	type Config struct {
+//		Timeout time.Duration `default:"5s"`
+//		Hosts   []string      `default:"a,b"`
+//	}
+//
+//	cfg := Config{}
+//	err := obj.ApplyDefaults(&cfg)
+func ApplyDefaults(v any) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return ErrNotAddresable
+	}
+	return applyDefaults(value.Elem())
+}
+
+// applyDefaults applies default tags to v and the values nested in it.
+func applyDefaults(v reflect.Value) error {
+	return applyNestedDefaults(v, make(map[walkVisitKey]bool))
+}
+
+// applyNestedDefaults applies default tags to v and the values nested in it
+// that aren't in visited, so that cycles of pointers and slices end.
+func applyNestedDefaults(v reflect.Value, visited map[walkVisitKey]bool) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		key := walkVisitKey{pointer: v.Pointer(), typ: v.Type()}
+		if visited[key] {
+			return nil
+		}
+		visited[key] = true
+		return applyNestedDefaults(v.Elem(), visited)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice {
+			key := walkVisitKey{pointer: v.Pointer(), length: v.Len(), typ: v.Type()}
+			if v.IsNil() || visited[key] {
+				return nil
+			}
+			visited[key] = true
+		}
+		for i := 0; i < v.Len(); i++ {
+			err := applyNestedDefaults(v.Index(i), visited)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if isTextValue(v.Type()) {
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			err := applyFieldDefault(field, v.Field(i))
+			if err != nil {
+				return err
+			}
+			err = applyNestedDefaults(v.Field(i), visited)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyFieldDefault sets v to the default value of field if v is zero.
+func applyFieldDefault(field reflect.StructField, v reflect.Value) error {
+	tag, ok := field.Tag.Lookup("default")
+	if !ok || !v.CanSet() || !v.IsZero() {
+		return nil
+	}
+	err := setString(v, tag, DefaultEnvSeparator)
+	if err != nil {
+		return fmt.Errorf("%s: %w", field.Name, err)
+	}
+	return nil
+}
+
+// applyFieldDefaults applies the default tag of a destination field after it
+// is mapped, and the default tags within it if it has no source, when
+// [MapperOptions.Defaults] is set.
+func (m *Mapper) applyFieldDefaults(field reflect.StructField, v reflect.Value, hasSource bool) error {
+	if !m.cfg.options.Defaults {
+		return nil
+	}
+	err := applyFieldDefault(field, v)
+	if err != nil {
+		return err
+	}
+	if !hasSource {
+		return applyDefaults(v)
+	}
+	return nil
+}
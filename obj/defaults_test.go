@@ -0,0 +1,118 @@
+package obj
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDefaultsServer struct {
+	Host    string        `default:"localhost"`
+	Port    int           `default:"8080"`
+	Timeout time.Duration `default:"5s"`
+}
+
+type testDefaultsConfig struct {
+	Name     string
+	Debug    bool     `default:"true"`
+	Ratio    float64  `default:"0.5"`
+	Tags     []string `default:"a,b"`
+	Server   testDefaultsServer
+	Backup   *testDefaultsServer
+	Replicas []testDefaultsServer
+}
+
+func TestApplyDefaults(t *testing.T) {
+	cfg := testDefaultsConfig{
+		Server:   testDefaultsServer{Port: 9090},
+		Backup:   &testDefaultsServer{Host: "backup"},
+		Replicas: []testDefaultsServer{{}},
+	}
+	err := ApplyDefaults(&cfg)
+
+	assert.Nil(t, err, "ApplyDefaults returned an error")
+	assert.Equal(t, testDefaultsConfig{
+		Debug:    true,
+		Ratio:    0.5,
+		Tags:     []string{"a", "b"},
+		Server:   testDefaultsServer{Host: "localhost", Port: 9090, Timeout: 5 * time.Second},
+		Backup:   &testDefaultsServer{Host: "backup", Port: 8080, Timeout: 5 * time.Second},
+		Replicas: []testDefaultsServer{{Host: "localhost", Port: 8080, Timeout: 5 * time.Second}},
+	}, cfg)
+}
+
+func TestApplyDefaultsErrors(t *testing.T) {
+	invalid := struct {
+		Port int `default:"port"`
+	}{}
+	assert.ErrorIs(t, ApplyDefaults(&invalid), ErrInvalidValue)
+	assert.Equal(t, ErrNotAddresable, ApplyDefaults(invalid))
+}
+
+func TestApplyDefaultsCycle(t *testing.T) {
+	type Node struct {
+		Name     string `default:"node"`
+		Next     *Node
+		Children []Node
+	}
+	n := &Node{}
+	n.Next = n
+	n.Children = make([]Node, 1)
+	n.Children[0].Children = n.Children
+
+	err := ApplyDefaults(n)
+
+	assert.Nil(t, err, "ApplyDefaults returned an error")
+	assert.Equal(t, "node", n.Name)
+	assert.Same(t, n, n.Next)
+	assert.Equal(t, "node", n.Children[0].Name)
+}
+
+func TestMapDefaults(t *testing.T) {
+	type ServerDTO struct {
+		Host string
+		Port int
+	}
+	type ConfigDTO struct {
+		Name   string
+		Ratio  float64
+		Server ServerDTO
+		Backup *ServerDTO
+	}
+
+	src := ConfigDTO{
+		Name:   "test",
+		Ratio:  0.25,
+		Server: ServerDTO{Host: "server"},
+		Backup: &ServerDTO{Port: 1},
+	}
+	dst := testDefaultsConfig{}
+	err := NewMapperWithOptions(MapperOptions{Defaults: true}).Map(src, &dst)
+
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, testDefaultsConfig{
+		Name:   "test",
+		Debug:  true,
+		Ratio:  0.25,
+		Tags:   []string{"a", "b"},
+		Server: testDefaultsServer{Host: "server", Port: 8080, Timeout: 5 * time.Second},
+		Backup: &testDefaultsServer{Host: "localhost", Port: 1, Timeout: 5 * time.Second},
+	}, dst)
+}
+
+func TestMapDefaultsDisabled(t *testing.T) {
+	type Settings struct {
+		Enabled bool `default:"true"`
+		N       int  `default:"5"`
+	}
+
+	dst := Settings{}
+	err := NewMapper().Map(Settings{Enabled: false, N: 0}, &dst)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, Settings{}, dst, "zero values of the source must be mapped as is")
+
+	clone, err := Clone(NewMapper(), Settings{})
+	assert.Nil(t, err, "Clone returned an error")
+	assert.Equal(t, Settings{}, clone)
+}
@@ -0,0 +1,78 @@
+package obj
+
+import (
+	"reflect"
+)
+
+// Derive creates a mapper that inherits the configuration and options of m.
+// Configuring the derived mapper doesn't affect m, while configuration that
+// the derived mapper doesn't override is looked up in m, including
+// configuration added to m after Derive is called.
+// Sample usage:
+//
+//	base := obj.NewMapper()
+//	err := obj.RegisterEnum(base, statusNames)
+//	...
+//	billing := base.Derive()
+//	err = obj.ConfigureFieldMaps[InvoiceDTO, Invoice](billing, ...)
+func (m *Mapper) Derive() *Mapper {
+	return m.DeriveWithOptions(m.cfg.options)
+}
+
+// DeriveWithOptions is like Derive but replaces the options of m with the given options.
+func (m *Mapper) DeriveWithOptions(options MapperOptions) *Mapper {
+	child := NewMapperWithOptions(options)
+	child.parent = m
+	return child
+}
+
+// fieldMapsOf returns the field maps of a pair of types, with those of m
+// taking precedence over those of its ancestors.
+func (m *Mapper) fieldMapsOf(key structMapKey) map[string]*FieldMapConfig {
+	fieldMaps := m.cfg.fieldMaps[key]
+	if m.parent == nil {
+		return fieldMaps
+	}
+	inherited := m.parent.fieldMapsOf(key)
+	if len(inherited) == 0 {
+		return fieldMaps
+	}
+	if len(fieldMaps) == 0 {
+		return inherited
+	}
+	merged := make(map[string]*FieldMapConfig, len(inherited)+len(fieldMaps))
+	for name, fieldMap := range inherited {
+		merged[name] = fieldMap
+	}
+	for name, fieldMap := range fieldMaps {
+		merged[name] = fieldMap
+	}
+	return merged
+}
+
+func (m *Mapper) sensitiveField(t reflect.Type, field string) (RedactionMode, bool) {
+	for mapper := m; mapper != nil; mapper = mapper.parent {
+		if mode, ok := mapper.cfg.sensitive[t][field]; ok {
+			return mode, true
+		}
+	}
+	return "", false
+}
+
+func (m *Mapper) enumOf(t reflect.Type) (*enumConfig, bool) {
+	for mapper := m; mapper != nil; mapper = mapper.parent {
+		if cfg, ok := mapper.cfg.enums[t]; ok {
+			return cfg, true
+		}
+	}
+	return nil, false
+}
+
+func (m *Mapper) collectionOf(t reflect.Type) (*CollectionConfig, bool) {
+	for mapper := m; mapper != nil; mapper = mapper.parent {
+		if cfg, ok := mapper.cfg.collections[t]; ok {
+			return cfg, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,60 @@
+package obj
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDeriveUserDTO struct {
+	Name     string
+	Nickname string
+	Password string
+	Status   string
+}
+
+type testDeriveUser struct {
+	FullName string
+	Alias    string
+	Password string
+	Status   testEnumStatus
+}
+
+func TestDerive(t *testing.T) {
+	base := NewMapper()
+	err := ConfigureFieldMaps[testDeriveUserDTO, testDeriveUser](base,
+		FieldMapConfig{Destination: "FullName", Source: "Name"},
+		FieldMapConfig{Destination: "Alias", Source: "Name"},
+	)
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+
+	child := base.DeriveWithOptions(MapperOptions{Redact: true})
+	err = ConfigureFieldMaps[testDeriveUserDTO, testDeriveUser](child,
+		FieldMapConfig{Destination: "Alias", Source: "Nickname"},
+	)
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+	err = ConfigureSensitiveFields[testDeriveUser](child, RedactLast4, "Password")
+	assert.Nil(t, err, "ConfigureSensitiveFields returned an error")
+
+	// configuration added to the parent after Derive is inherited
+	err = RegisterEnum(base, map[testEnumStatus]string{testEnumStatusActive: "ACTIVE"})
+	assert.Nil(t, err, "RegisterEnum returned an error")
+
+	src := testDeriveUserDTO{Name: "John", Nickname: "Johnny", Password: "secret-1234", Status: "ACTIVE"}
+
+	user := testDeriveUser{}
+	err = child.Map(src, &user)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, testDeriveUser{FullName: "John", Alias: "Johnny", Password: "*******1234", Status: testEnumStatusActive}, user)
+
+	user = testDeriveUser{}
+	err = base.Map(src, &user)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, testDeriveUser{FullName: "John", Alias: "John", Password: "secret-1234", Status: testEnumStatusActive}, user)
+
+	user = testDeriveUser{}
+	err = child.Derive().Map(src, &user)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, "Johnny", user.Alias)
+	assert.Equal(t, "*******1234", user.Password, "options are inherited")
+}
@@ -0,0 +1,211 @@
+package obj
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Description describes a type and the structs reachable from it, see [Mapper.Describe].
+type Description struct {
+	Root *TypeDescription
+
+	// Structs contains the description of every struct reachable from Root,
+	// so that recursive types are described once.
+	Structs map[reflect.Type]*StructDescription
+}
+
+// TypeDescription describes a type. Pointers are dereferenced.
+type TypeDescription struct {
+	// Type is the type without pointers
+	Type reflect.Type
+
+	Kind reflect.Kind
+
+	// Nullable indicates that values can be nil and are encoded to JSON as
+	// null: pointers, slices and maps
+	Nullable bool
+
+	// Elem is the element type of slices, arrays and maps
+	Elem *TypeDescription
+
+	// Key is the key type of maps
+	Key *TypeDescription
+
+	// Enum contains the names of the values of enums registered with the mapper, in order of value
+	Enum []string
+
+	// EnumValues contains the values named by Enum, in the same order
+	EnumValues []any
+
+	// Text indicates that the type implements encoding.TextMarshaler, e.g. time.Time
+	Text bool
+
+	// CustomJSON indicates that the type implements json.Marshaler
+	CustomJSON bool
+}
+
+// StructDescription describes the exported fields and accessors of a struct.
+type StructDescription struct {
+	Type    reflect.Type
+	Fields  []FieldDescription
+	Getters []AccessorDescription
+	Setters []AccessorDescription
+}
+
+// FieldDescription describes an exported struct field.
+type FieldDescription struct {
+	Name string
+
+	// JSONName is the name of the field in JSON, empty if the field isn't encoded
+	JSONName string
+
+	// OmitEmpty indicates that the json tag has the omitempty option
+	OmitEmpty bool
+
+	// Required indicates that the field is always present in JSON: it isn't a pointer and isn't omitempty
+	Required bool
+
+	// Embedded indicates that the field is an embedded struct
+	Embedded bool
+
+	Tag  reflect.StructTag
+	Type *TypeDescription
+}
+
+// AccessorDescription describes a getter, e.g. GetName, or a setter, e.g. SetName.
+type AccessorDescription struct {
+	// Name is the name of the method
+	Name string
+
+	// Field is the name of the method without its Get or Set prefix
+	Field string
+
+	// Type is the type returned by getters or accepted by setters
+	Type *TypeDescription
+}
+
+// Describe returns the description of T. See [Mapper.Describe].
+// Sample usage:
+//
+//	desc, err := obj.Describe[User](mapper)
+//	...
+//	schema, err := desc.JSONSchema()
+func Describe[T any](mapper *Mapper) (*Description, error) {
+	return mapper.Describe(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// Describe returns the description of t as seen by the mapper: the exported
+// fields, getters and setters of structs, nested and recursive types, and the
+// names of enums registered with the mapper.
+func (m *Mapper) Describe(t reflect.Type) (*Description, error) {
+	if t == nil {
+		return nil, fmt.Errorf("type must be provided")
+	}
+	d := &Description{Structs: make(map[reflect.Type]*StructDescription)}
+	d.Root = m.describeType(d, t)
+	return d, nil
+}
+
+func (m *Mapper) describeType(d *Description, t reflect.Type) *TypeDescription {
+	desc := &TypeDescription{}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		desc.Nullable = true
+	}
+	desc.Type, desc.Kind = t, t.Kind()
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		desc.Nullable = true
+	}
+	if enum, ok := m.enumOf(t); ok {
+		desc.Enum, desc.EnumValues = enum.sorted()
+	}
+	desc.CustomJSON = implements(t, jsonMarshalerType)
+	desc.Text = implements(t, textMarshalerType)
+	if desc.Enum != nil || desc.CustomJSON || desc.Text {
+		return desc
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		desc.Elem = m.describeType(d, t.Elem())
+	case reflect.Map:
+		desc.Key = m.describeType(d, t.Key())
+		desc.Elem = m.describeType(d, t.Elem())
+	case reflect.Struct:
+		m.describeStruct(d, t)
+	}
+	return desc
+}
+
+func (m *Mapper) describeStruct(d *Description, t reflect.Type) {
+	if _, ok := d.Structs[t]; ok {
+		return
+	}
+	desc := &StructDescription{Type: t}
+	// registered before the fields are described to stop recursion
+	d.Structs[t] = desc
+
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() && (!structField.Anonymous || indirectType(structField.Type).Kind() != reflect.Struct) {
+			continue
+		}
+		field := FieldDescription{
+			Name:     structField.Name,
+			Embedded: structField.Anonymous,
+			Tag:      structField.Tag,
+			Type:     m.describeType(d, structField.Type),
+		}
+		if name, ok := jsonFieldName(structField); ok {
+			field.JSONName = name
+			_, options, _ := strings.Cut(structField.Tag.Get("json"), ",")
+			field.OmitEmpty = hasTagOption(options, "omitempty")
+		}
+		field.Required = field.JSONName != "" && !field.OmitEmpty && structField.Type.Kind() != reflect.Pointer
+		desc.Fields = append(desc.Fields, field)
+	}
+
+	ptrType := reflect.PointerTo(t)
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		method := ptrType.Method(i)
+		switch {
+		case strings.HasPrefix(method.Name, "Get") && isGetter(t, method.Name):
+			desc.Getters = append(desc.Getters, AccessorDescription{
+				Name:  method.Name,
+				Field: method.Name[3:],
+				Type:  m.describeType(d, method.Type.Out(0)),
+			})
+		case isSetter(method):
+			desc.Setters = append(desc.Setters, AccessorDescription{
+				Name:  method.Name,
+				Field: method.Name[3:],
+				Type:  m.describeType(d, method.Type.In(1)),
+			})
+		}
+	}
+}
+
+// sorted returns the names and the values of the enum in order of value.
+func (cfg *enumConfig) sorted() ([]string, []any) {
+	values := make([]reflect.Value, 0, len(cfg.values))
+	for _, value := range cfg.values {
+		values = append(values, value)
+	}
+	sortValues(values)
+	names := make([]string, 0, len(values))
+	sorted := make([]any, 0, len(values))
+	for _, value := range values {
+		names = append(names, cfg.names[value.Interface()])
+		sorted = append(sorted, value.Interface())
+	}
+	return names, sorted
+}
+
+// implements reports whether t or a pointer to t implements iface.
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
@@ -0,0 +1,99 @@
+package obj
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDescribeStatus int
+
+const (
+	testDescribeActive testDescribeStatus = iota + 1
+	testDescribeSuspended
+)
+
+type testDescribeAudit struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testDescribeUser struct {
+	testDescribeAudit
+	ID       int                `json:"id"`
+	Name     string             `json:"name,omitempty"`
+	Status   testDescribeStatus `json:"status"`
+	Manager  *testDescribeUser  `json:"manager"`
+	Tags     map[string]string  `json:"tags"`
+	Password string             `json:"-"`
+	password string
+}
+
+func (u testDescribeUser) GetDisplayName() string {
+	return u.Name
+}
+
+func (u *testDescribeUser) SetNickname(name string) {
+	u.Name = name
+}
+
+func newTestDescribeMapper(t *testing.T) *Mapper {
+	mapper := NewMapper()
+	err := RegisterEnum(mapper, map[testDescribeStatus]string{
+		testDescribeSuspended: "SUSPENDED",
+		testDescribeActive:    "ACTIVE",
+	})
+	assert.Nil(t, err, "RegisterEnum returned an error")
+	return mapper
+}
+
+func TestDescribe(t *testing.T) {
+	desc, err := Describe[*testDescribeUser](newTestDescribeMapper(t))
+	assert.Nil(t, err, "Describe returned an error")
+
+	userType := reflect.TypeOf(testDescribeUser{})
+	assert.Equal(t, userType, desc.Root.Type)
+	assert.True(t, desc.Root.Nullable)
+	assert.Len(t, desc.Structs, 2)
+
+	user := desc.Structs[userType]
+	names := make([]string, 0, len(user.Fields))
+	for _, field := range user.Fields {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"testDescribeAudit", "ID", "Name", "Status", "Manager", "Tags", "Password"}, names)
+
+	assert.True(t, user.Fields[0].Embedded)
+	assert.Equal(t, FieldDescription{
+		Name:     "ID",
+		JSONName: "id",
+		Required: true,
+		Tag:      `json:"id"`,
+		Type:     &TypeDescription{Type: reflect.TypeOf(0), Kind: reflect.Int},
+	}, user.Fields[1])
+	assert.True(t, user.Fields[2].OmitEmpty)
+	assert.False(t, user.Fields[2].Required)
+	assert.Equal(t, []string{"ACTIVE", "SUSPENDED"}, user.Fields[3].Type.Enum)
+	assert.Equal(t, []any{testDescribeActive, testDescribeSuspended}, user.Fields[3].Type.EnumValues)
+	assert.True(t, user.Fields[4].Type.Nullable)
+	assert.False(t, user.Fields[4].Required)
+	assert.Equal(t, reflect.String, user.Fields[5].Type.Key.Kind)
+	assert.Equal(t, reflect.String, user.Fields[5].Type.Elem.Kind)
+	assert.Equal(t, "", user.Fields[6].JSONName)
+
+	assert.Len(t, user.Getters, 1)
+	assert.Equal(t, "GetDisplayName", user.Getters[0].Name)
+	assert.Equal(t, "DisplayName", user.Getters[0].Field)
+	assert.Len(t, user.Setters, 1)
+	assert.Equal(t, "Nickname", user.Setters[0].Field)
+	assert.Equal(t, reflect.String, user.Setters[0].Type.Kind)
+
+	audit := desc.Structs[reflect.TypeOf(testDescribeAudit{})]
+	assert.True(t, audit.Fields[0].Type.Text)
+}
+
+func TestDescribeNilType(t *testing.T) {
+	_, err := NewMapper().Describe(nil)
+	assert.EqualError(t, err, "type must be provided")
+}
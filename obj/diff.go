@@ -0,0 +1,446 @@
+package obj
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Change describes a value that differs between two objects.
+type Change struct {
+	// Path of the value, e.g. Address.City, Items[2].Qty or Labels[env]
+	Path string
+
+	// Old is the value in the first object or nil if it's not present.
+	Old any
+
+	// New is the value in the second object or nil if it's not present.
+	New any
+}
+
+// DiffOption customizes the behavior of Diff.
+type DiffOption func(*diffOptions)
+
+type diffOptions struct {
+	mapper *Mapper
+	keys   []string
+
+	// the options below are set by Equal
+	ignorePaths  []string
+	ignoreFields map[string]bool
+	nilAsEmpty   bool
+	tolerance    float64
+	strict       bool
+}
+
+// WithDiffMapper matches the fields of different struct types using the field
+// maps configured in mapper, the first value being the source and the second
+// the destination. Ignored fields are not compared.
+func WithDiffMapper(mapper *Mapper) DiffOption {
+	return func(o *diffOptions) {
+		o.mapper = mapper
+	}
+}
+
+// WithDiffKey matches the elements of slices of structs by the value of the
+// given field instead of their index. When called multiple times, the first
+// field found in the element type is used.
+func WithDiffKey(field string) DiffOption {
+	return func(o *diffOptions) {
+		o.keys = append(o.keys, field)
+	}
+}
+
+// Diff compares a and b and returns the values that changed from a to b.
+// Structs are compared field by field, slices and arrays by index (or by key, see
+// [WithDiffKey]) and maps by key. Unexported fields, functions and channels are not compared.
+// Sample usage:
+//
+//	changes, err := obj.Diff(before, after, obj.WithDiffKey("ID"))
+//	if err != nil {
+//		return err
+//	}
+//	for _, change := range changes {
+//		log.Printf("%s changed from %v to %v", change.Path, change.Old, change.New)
+//	}
+func Diff(a any, b any, opts ...DiffOption) ([]Change, error) {
+	options := diffOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	d := differ{
+		options:  &options,
+		visiting: make(map[visitKey]bool),
+	}
+	err := d.diff("", reflect.ValueOf(a), reflect.ValueOf(b))
+	if err != nil {
+		return nil, err
+	}
+	return d.changes, nil
+}
+
+type visitKey struct {
+	a   uintptr
+	b   uintptr
+	typ reflect.Type
+}
+
+type differ struct {
+	options *diffOptions
+
+	// visiting contains the pointer pairs being compared, to detect cycles.
+	// Pairs reached again through another path are compared again.
+	visiting map[visitKey]bool
+	changes  []Change
+
+	// ignored are the parsed ignorePaths of the options
+	ignored [][]pathSegment
+}
+
+// enter marks the pointers a and b as being compared and reports whether
+// they weren't already.
+func (d *differ) enter(a reflect.Value, b reflect.Value) bool {
+	key := visitKey{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+	if d.visiting[key] {
+		return false
+	}
+	d.visiting[key] = true
+	return true
+}
+
+func (d *differ) leave(a reflect.Value, b reflect.Value) {
+	delete(d.visiting, visitKey{a: a.Pointer(), b: b.Pointer(), typ: a.Type()})
+}
+
+func (d *differ) add(path string, a reflect.Value, b reflect.Value) {
+	d.changes = append(d.changes, Change{
+		Path: path,
+		Old:  valueInterface(a),
+		New:  valueInterface(b),
+	})
+}
+
+func valueInterface(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func (d *differ) diff(path string, a reflect.Value, b reflect.Value) error {
+	if d.options.strict && len(d.changes) > 0 {
+		return nil // Equal only needs the first change
+	}
+	if len(d.ignored) > 0 && d.isIgnored(path) {
+		return nil
+	}
+	if a.IsValid() && (a.Kind() == reflect.Interface || a.Kind() == reflect.Pointer) &&
+		b.IsValid() && (b.Kind() == reflect.Interface || b.Kind() == reflect.Pointer) {
+		if a.IsNil() && b.IsNil() {
+			return nil
+		}
+		if a.IsNil() || b.IsNil() {
+			d.add(path, a, b)
+			return nil
+		}
+		if a.Kind() == reflect.Pointer && b.Kind() == reflect.Pointer {
+			if !d.enter(a, b) {
+				return nil // a cycle, compared when first reached
+			}
+			defer d.leave(a, b)
+		}
+		if a.Kind() == reflect.Interface && b.Kind() == reflect.Interface && a.Elem().Type() != b.Elem().Type() {
+			d.add(path, a, b)
+			return nil
+		}
+		return d.diff(path, a.Elem(), b.Elem())
+	}
+	if a.IsValid() && (a.Kind() == reflect.Interface || a.Kind() == reflect.Pointer) {
+		if a.IsNil() {
+			d.add(path, a, b)
+			return nil
+		}
+		return d.diff(path, a.Elem(), b)
+	}
+	if b.IsValid() && (b.Kind() == reflect.Interface || b.Kind() == reflect.Pointer) {
+		if b.IsNil() {
+			d.add(path, a, b)
+			return nil
+		}
+		return d.diff(path, a, b.Elem())
+	}
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() || b.IsValid() {
+			d.add(path, a, b)
+		}
+		return nil
+	}
+
+	if d.options.strict && d.options.mapper == nil && a.Type() != b.Type() {
+		d.add(path, a, b)
+		return nil
+	}
+	if d.options.strict && !d.options.nilAsEmpty && isNilCollection(a.Kind()) && isNilCollection(b.Kind()) && a.IsNil() != b.IsNil() {
+		d.add(path, a, b)
+		return nil
+	}
+
+	if equal, ok := callEqual(a, b); ok {
+		if !equal {
+			d.add(path, a, b)
+		}
+		return nil
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		if b.Kind() != reflect.Struct {
+			return fmt.Errorf("%s: %w", path, ErrMismatchType)
+		}
+		return d.diffStruct(path, a, b)
+	case reflect.Slice, reflect.Array:
+		if b.Kind() != reflect.Slice && b.Kind() != reflect.Array {
+			return fmt.Errorf("%s: %w", path, ErrMismatchType)
+		}
+		if key := d.sliceKey(a.Type().Elem(), b.Type().Elem()); key != "" {
+			return d.diffSliceByKey(path, a, b, key)
+		}
+		return d.diffSlice(path, a, b)
+	case reflect.Map:
+		if b.Kind() != reflect.Map || a.Type().Key() != b.Type().Key() {
+			return fmt.Errorf("%s: %w", path, ErrMismatchType)
+		}
+		return d.diffMap(path, a, b)
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer, reflect.Uintptr:
+		return nil
+	}
+
+	equal, err := scalarEqual(a, b, d.options.tolerance)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if !equal {
+		d.add(path, a, b)
+	}
+	return nil
+}
+
+// callEqual compares values of the same type using their Equal method if
+// present, e.g. time.Time.
+func callEqual(a reflect.Value, b reflect.Value) (bool, bool) {
+	if a.Type() != b.Type() || !a.CanInterface() || !b.CanInterface() {
+		return false, false
+	}
+	method := a.MethodByName("Equal")
+	if !method.IsValid() || method.Type().NumIn() != 1 || method.Type().In(0) != a.Type() ||
+		method.Type().NumOut() != 1 || method.Type().Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	return method.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+// scalarEqual compares values of basic kinds. Floats are equal if they differ
+// by at most tolerance.
+func scalarEqual(a reflect.Value, b reflect.Value, tolerance float64) (bool, error) {
+	if a.Kind() != b.Kind() {
+		return false, ErrMismatchType
+	}
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() == b.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float() || math.Abs(a.Float()-b.Float()) <= tolerance, nil
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex(), nil
+	case reflect.String:
+		return a.String() == b.String(), nil
+	}
+	return false, ErrMismatchType
+}
+
+func (d *differ) diffStruct(path string, a reflect.Value, b reflect.Value) error {
+	var fieldMaps map[string]*FieldMapConfig
+	if d.options.mapper != nil {
+		fieldMaps = d.options.mapper.fieldMapsOf(structMapKey{
+			source:      a.Type(),
+			destination: b.Type(),
+		})
+	}
+
+	for i := 0; i < b.NumField(); i++ {
+		field := b.Type().Field(i)
+		if !field.IsExported() || d.options.ignoreFields[field.Name] {
+			continue
+		}
+		fieldMap := fieldMaps[field.Name]
+		if fieldMap != nil && fieldMap.Ignore {
+			continue
+		}
+		srcField, ok := a.Type().FieldByName(sourceFieldName(fieldMap, field.Name))
+		if !ok || !srcField.IsExported() {
+			continue
+		}
+		// invalid if promoted through a nil embedded pointer, reported as missing
+		srcValue, _ := fieldByIndex(a, srcField.Index, false)
+		err := d.diff(joinFieldPath(path, field.Name), srcValue, b.Field(i))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *differ) diffSlice(path string, a reflect.Value, b reflect.Value) error {
+	for i := 0; i < a.Len() || i < b.Len(); i++ {
+		itemPath := joinIndexPath(path, i)
+		if i >= a.Len() {
+			d.add(itemPath, reflect.Value{}, b.Index(i))
+			continue
+		}
+		if i >= b.Len() {
+			d.add(itemPath, a.Index(i), reflect.Value{})
+			continue
+		}
+		err := d.diff(itemPath, a.Index(i), b.Index(i))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sliceKey returns the identity key configured for the element types or an empty string.
+func (d *differ) sliceKey(a reflect.Type, b reflect.Type) string {
+	a, b = indirectType(a), indirectType(b)
+	if a.Kind() != reflect.Struct || b.Kind() != reflect.Struct {
+		return ""
+	}
+	for _, key := range d.options.keys {
+		_, okA := a.FieldByName(key)
+		_, okB := b.FieldByName(key)
+		if okA && okB {
+			return key
+		}
+	}
+	return ""
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func (d *differ) diffSliceByKey(path string, a reflect.Value, b reflect.Value, key string) error {
+	aIndexes := make(map[any]int, a.Len())
+	for i := 0; i < a.Len(); i++ {
+		if k, ok := elemKey(a.Index(i), key); ok {
+			aIndexes[k] = i
+		}
+	}
+
+	matched := make(map[any]bool, b.Len())
+	for i := 0; i < b.Len(); i++ {
+		k, ok := elemKey(b.Index(i), key)
+		if !ok {
+			d.add(joinIndexPath(path, i), reflect.Value{}, b.Index(i))
+			continue
+		}
+		itemPath := path + "[" + key + "=" + fmt.Sprint(k) + "]"
+		j, found := aIndexes[k]
+		if !found {
+			d.add(itemPath, reflect.Value{}, b.Index(i))
+			continue
+		}
+		matched[k] = true
+		err := d.diff(itemPath, a.Index(j), b.Index(i))
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < a.Len(); i++ {
+		k, ok := elemKey(a.Index(i), key)
+		if !ok {
+			d.add(joinIndexPath(path, i), a.Index(i), reflect.Value{})
+			continue
+		}
+		if !matched[k] {
+			d.add(path+"["+key+"="+fmt.Sprint(k)+"]", a.Index(i), reflect.Value{})
+		}
+	}
+	return nil
+}
+
+// elemKey returns the comparable value of the key field of a struct element.
+func elemKey(elem reflect.Value, key string) (any, bool) {
+	for elem.Kind() == reflect.Pointer || elem.Kind() == reflect.Interface {
+		if elem.IsNil() {
+			return nil, false
+		}
+		elem = elem.Elem()
+	}
+	field := elem.FieldByName(key)
+	if !field.IsValid() || !field.CanInterface() || !field.Type().Comparable() {
+		return nil, false
+	}
+	return field.Interface(), true
+}
+
+func (d *differ) diffMap(path string, a reflect.Value, b reflect.Value) error {
+	keys := a.MapKeys()
+	for _, key := range b.MapKeys() {
+		if !a.MapIndex(key).IsValid() {
+			keys = append(keys, key)
+		}
+	}
+	sortValues(keys)
+
+	for _, key := range keys {
+		keyPath := joinKeyPath(path, key)
+		aVal := a.MapIndex(key)
+		bVal := b.MapIndex(key)
+		if !aVal.IsValid() || !bVal.IsValid() {
+			d.add(keyPath, aVal, bVal)
+			continue
+		}
+		err := d.diff(keyPath, aVal, bVal)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortValues sorts map keys so that results don't depend on map iteration order.
+func sortValues(values []reflect.Value) {
+	sort.SliceStable(values, func(i, j int) bool {
+		return valueLess(values[i], values[j])
+	})
+}
+
+func valueLess(a reflect.Value, b reflect.Value) bool {
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		}
+	}
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
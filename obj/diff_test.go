@@ -0,0 +1,196 @@
+package obj
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDiffItem struct {
+	ID  int
+	Qty int
+}
+
+type testDiffOrder struct {
+	ID        int
+	Customer  *testUser
+	Items     []testDiffItem
+	Labels    map[string]string
+	UpdatedAt time.Time
+	Notes     any
+}
+
+func TestDiff(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		a        any
+		b        any
+		opts     []DiffOption
+		expected []Change
+	}{
+		{
+			name:     "Equal",
+			a:        testDiffOrder{ID: 1, Items: []testDiffItem{{1, 1}}, UpdatedAt: now},
+			b:        testDiffOrder{ID: 1, Items: []testDiffItem{{1, 1}}, UpdatedAt: now},
+			expected: nil,
+		},
+		{
+			name: "Fields",
+			a:    testDiffOrder{ID: 1, Customer: &testUser{ID: 1, Name: "John"}, UpdatedAt: now},
+			b:    &testDiffOrder{ID: 2, Customer: &testUser{ID: 1, Name: "Jane"}, UpdatedAt: now.Add(time.Second)},
+			expected: []Change{
+				{Path: "ID", Old: 1, New: 2},
+				{Path: "Customer.Name", Old: "John", New: "Jane"},
+				{Path: "UpdatedAt", Old: now, New: now.Add(time.Second)},
+			},
+		},
+		{
+			name: "Nil pointer",
+			a:    testDiffOrder{},
+			b:    testDiffOrder{Customer: &testUser{ID: 1}},
+			expected: []Change{
+				{Path: "Customer", Old: nil, New: &testUser{ID: 1}},
+			},
+		},
+		{
+			name: "Interface",
+			a:    testDiffOrder{Notes: "a"},
+			b:    testDiffOrder{Notes: 1},
+			expected: []Change{
+				{Path: "Notes", Old: "a", New: 1},
+			},
+		},
+		{
+			name: "Slice by index",
+			a:    testDiffOrder{Items: []testDiffItem{{1, 1}, {2, 2}}},
+			b:    testDiffOrder{Items: []testDiffItem{{2, 2}}},
+			expected: []Change{
+				{Path: "Items[0].ID", Old: 1, New: 2},
+				{Path: "Items[0].Qty", Old: 1, New: 2},
+				{Path: "Items[1]", Old: testDiffItem{2, 2}, New: nil},
+			},
+		},
+		{
+			name: "Slice by key",
+			a:    testDiffOrder{Items: []testDiffItem{{1, 1}, {2, 2}}},
+			b:    testDiffOrder{Items: []testDiffItem{{2, 3}, {3, 3}}},
+			opts: []DiffOption{WithDiffKey("SKU"), WithDiffKey("ID")},
+			expected: []Change{
+				{Path: "Items[ID=2].Qty", Old: 2, New: 3},
+				{Path: "Items[ID=3]", Old: nil, New: testDiffItem{3, 3}},
+				{Path: "Items[ID=1]", Old: testDiffItem{1, 1}, New: nil},
+			},
+		},
+		{
+			name: "Map",
+			a:    testDiffOrder{Labels: map[string]string{"env": "dev", "team": "a", "a.b": "c"}},
+			b:    testDiffOrder{Labels: map[string]string{"env": "prod", "tier": "1", "a.b": "c"}},
+			expected: []Change{
+				{Path: "Labels[env]", Old: "dev", New: "prod"},
+				{Path: "Labels[team]", Old: "a", New: nil},
+				{Path: "Labels[tier]", Old: nil, New: "1"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := Diff(test.a, test.b, test.opts...)
+			assert.Nil(t, err, "Diff returned an error")
+			assert.Equal(t, test.expected, changes)
+		})
+	}
+}
+
+func TestDiffWithMapper(t *testing.T) {
+	type UserDTO struct {
+		ID       int
+		FullName string
+		Password string
+	}
+	type User struct {
+		ID       int
+		Name     string
+		Password string
+	}
+
+	mapper := NewMapper()
+	err := ConfigureFieldMaps[UserDTO, User](mapper,
+		FieldMapConfig{Source: "FullName", Destination: "Name"},
+		FieldMapConfig{Destination: "Password", Ignore: true},
+	)
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+
+	changes, err := Diff(
+		UserDTO{ID: 1, FullName: "John", Password: "a"},
+		User{ID: 1, Name: "Jane", Password: "b"},
+		WithDiffMapper(mapper),
+	)
+	assert.Nil(t, err, "Diff returned an error")
+	assert.Equal(t, []Change{{Path: "Name", Old: "John", New: "Jane"}}, changes)
+}
+
+func TestDiffNilEmbeddedPointer(t *testing.T) {
+	type Embedded struct {
+		X int
+	}
+	type Outer struct {
+		*Embedded
+	}
+	type Flat struct {
+		X int
+	}
+
+	changes, err := Diff(Outer{}, Flat{X: 1})
+	assert.Nil(t, err, "Diff returned an error")
+	assert.Equal(t, []Change{{Path: "X", Old: nil, New: 1}}, changes)
+
+	changes, err = Diff(Outer{Embedded: &Embedded{X: 1}}, Flat{X: 1})
+	assert.Nil(t, err, "Diff returned an error")
+	assert.Empty(t, changes)
+}
+
+func TestDiffMismatchType(t *testing.T) {
+	_, err := Diff(testDiffOrder{}, 1)
+	assert.ErrorIs(t, err, ErrMismatchType)
+
+	_, err = Diff(struct{ ID string }{}, struct{ ID int }{})
+	assert.ErrorIs(t, err, ErrMismatchType)
+	assert.Contains(t, err.Error(), "ID")
+}
+
+func TestDiffCycle(t *testing.T) {
+	type Node struct {
+		Value int
+		Next  *Node
+	}
+	a := &Node{Value: 1}
+	a.Next = a
+	b := &Node{Value: 2}
+	b.Next = b
+
+	changes, err := Diff(a, b)
+	assert.Nil(t, err, "Diff returned an error")
+	assert.Equal(t, []Change{{Path: "Value", Old: 1, New: 2}}, changes)
+}
+
+func TestDiffSharedPointer(t *testing.T) {
+	type Inner struct {
+		V int
+	}
+	type Outer struct {
+		X *Inner
+		Y *Inner
+	}
+	a := &Inner{V: 1}
+	b := &Inner{V: 2}
+
+	changes, err := Diff(Outer{X: a, Y: a}, Outer{X: b, Y: b})
+	assert.Nil(t, err, "Diff returned an error")
+	assert.Equal(t, []Change{
+		{Path: "X.V", Old: 1, New: 2},
+		{Path: "Y.V", Old: 1, New: 2},
+	}, changes)
+}
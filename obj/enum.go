@@ -0,0 +1,174 @@
+package obj
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownEnum returned when a value doesn't match any of the names of a registered enum.
+var ErrUnknownEnum error = fmt.Errorf("unknown enum value")
+
+// UnknownEnumPolicy indicates how values that are not registered are mapped.
+type UnknownEnumPolicy int
+
+const (
+	// UnknownEnumError fails with ErrUnknownEnum.
+	UnknownEnumError UnknownEnumPolicy = 0
+
+	// UnknownEnumDefault maps unknown values to [EnumOptions.Default].
+	UnknownEnumDefault UnknownEnumPolicy = 1
+
+	// UnknownEnumPassthrough maps unknown values to their number, e.g. 7 to "7" and back.
+	UnknownEnumPassthrough UnknownEnumPolicy = 2
+)
+
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// EnumOptions contains settings of an enum registered with [RegisterEnumWithOptions].
+type EnumOptions[E integer] struct {
+	// CaseInsensitive allows names to be matched regardless of case when mapping from strings.
+	CaseInsensitive bool
+
+	// Unknown indicates how values without a name are mapped. Defaults to UnknownEnumError.
+	Unknown UnknownEnumPolicy
+
+	// Default is used for unknown values with UnknownEnumDefault.
+	Default E
+}
+
+type enumConfig struct {
+	typ             reflect.Type
+	names           map[any]string
+	values          map[string]reflect.Value
+	caseInsensitive bool
+	unknown         UnknownEnumPolicy
+	defaultValue    reflect.Value
+}
+
+// RegisterEnum registers the names of the values of E. The mapper then maps
+// between E and strings (or types with a string kind) using these names.
+// Sample usage:
+//
+//	type Status int
+//
+//	const (
+//		StatusActive Status = iota + 1
+//		StatusSuspended
+//	)
+//
+//	err := obj.RegisterEnum(mapper, map[Status]string{
+//		StatusActive:    "ACTIVE",
+//		StatusSuspended: "SUSPENDED",
+//	})
+func RegisterEnum[E integer](mapper *Mapper, names map[E]string) error {
+	return RegisterEnumWithOptions(mapper, names, EnumOptions[E]{})
+}
+
+// RegisterEnumWithOptions is like [RegisterEnum] but allows case-insensitive
+// matching and handling of unknown values.
+func RegisterEnumWithOptions[E integer](mapper *Mapper, names map[E]string, options EnumOptions[E]) error {
+	cfg := &enumConfig{
+		typ:             reflect.TypeOf(options.Default),
+		names:           make(map[any]string, len(names)),
+		values:          make(map[string]reflect.Value, len(names)),
+		caseInsensitive: options.CaseInsensitive,
+		unknown:         options.Unknown,
+		defaultValue:    reflect.ValueOf(options.Default),
+	}
+	if options.Unknown == UnknownEnumDefault {
+		if _, ok := names[options.Default]; !ok {
+			return fmt.Errorf("default value %v has no name", options.Default)
+		}
+	}
+
+	values := make([]E, 0, len(names))
+	for value := range names {
+		values = append(values, value)
+	}
+	slices.Sort(values)
+	for _, value := range values {
+		name := names[value]
+		key := cfg.key(name)
+		if _, ok := cfg.values[key]; ok {
+			return fmt.Errorf("duplicate enum name %q", name)
+		}
+		cfg.names[value] = name
+		cfg.values[key] = reflect.ValueOf(value)
+	}
+	mapper.cfg.enums[cfg.typ] = cfg
+	return nil
+}
+
+func (cfg *enumConfig) key(name string) string {
+	if cfg.caseInsensitive {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// parse returns the value of E named s.
+func (cfg *enumConfig) parse(s string) (reflect.Value, error) {
+	if value, ok := cfg.values[cfg.key(s)]; ok {
+		return value, nil
+	}
+	switch cfg.unknown {
+	case UnknownEnumDefault:
+		return cfg.defaultValue, nil
+	case UnknownEnumPassthrough:
+		value := reflect.New(cfg.typ).Elem()
+		if setString(value, s, DefaultEnvSeparator) == nil {
+			return value, nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("%w: %q for %s", ErrUnknownEnum, s, cfg.typ)
+}
+
+// format returns the name of the value of E.
+func (cfg *enumConfig) format(v reflect.Value) (string, error) {
+	v = v.Convert(cfg.typ)
+	if name, ok := cfg.names[v.Interface()]; ok {
+		return name, nil
+	}
+	switch cfg.unknown {
+	case UnknownEnumDefault:
+		return cfg.names[cfg.defaultValue.Interface()], nil
+	case UnknownEnumPassthrough:
+		if v.CanInt() {
+			return strconv.FormatInt(v.Int(), 10), nil
+		}
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("%w: %v for %s", ErrUnknownEnum, v.Interface(), cfg.typ)
+}
+
+// mapEnum maps between registered enums and strings. It returns false if
+// neither src nor dst is a registered enum.
+func (m *Mapper) mapEnum(src reflect.Value, dst reflect.Value) (bool, error) {
+	if src.Kind() == reflect.String && dst.Kind() != reflect.String {
+		if cfg, ok := m.enumOf(dst.Type()); ok {
+			value, err := cfg.parse(src.String())
+			if err != nil {
+				return true, err
+			}
+			dst.Set(value.Convert(dst.Type()))
+			return true, nil
+		}
+	}
+
+	if dst.Kind() == reflect.String && src.Kind() != reflect.String {
+		if cfg, ok := m.enumOf(src.Type()); ok {
+			name, err := cfg.format(src)
+			if err != nil {
+				return true, err
+			}
+			dst.SetString(name)
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,168 @@
+package obj
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEnumStatus int
+
+const (
+	testEnumStatusUnknown testEnumStatus = iota
+	testEnumStatusActive
+	testEnumStatusSuspended
+)
+
+var testEnumStatusNames = map[testEnumStatus]string{
+	testEnumStatusUnknown:   "UNKNOWN",
+	testEnumStatusActive:    "ACTIVE",
+	testEnumStatusSuspended: "SUSPENDED",
+}
+
+type testEnumAccount struct {
+	ID     int
+	Status testEnumStatus
+}
+
+type testEnumAccountDTO struct {
+	ID     int
+	Status string
+}
+
+func TestMapEnum(t *testing.T) {
+	mapper := NewMapper()
+	err := RegisterEnum(mapper, testEnumStatusNames)
+	assert.Nil(t, err, "RegisterEnum returned an error")
+
+	dto := testEnumAccountDTO{}
+	err = mapper.Map(testEnumAccount{ID: 1, Status: testEnumStatusSuspended}, &dto)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, testEnumAccountDTO{ID: 1, Status: "SUSPENDED"}, dto)
+
+	account := testEnumAccount{}
+	err = mapper.Map(testEnumAccountDTO{ID: 1, Status: "ACTIVE"}, &account)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, testEnumAccount{ID: 1, Status: testEnumStatusActive}, account)
+
+	err = mapper.Map(testEnumAccountDTO{Status: "active"}, &account)
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+
+	err = mapper.Map(testEnumAccount{Status: 9}, &dto)
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+}
+
+func TestMapEnumWithOptions(t *testing.T) {
+	tests := []struct {
+		name           string
+		options        EnumOptions[testEnumStatus]
+		src            string
+		expected       testEnumStatus
+		expectedString string
+		err            error
+	}{
+		{
+			name:           "Case insensitive",
+			options:        EnumOptions[testEnumStatus]{CaseInsensitive: true},
+			src:            "suspended",
+			expected:       testEnumStatusSuspended,
+			expectedString: "SUSPENDED",
+		},
+		{
+			name:           "Default",
+			options:        EnumOptions[testEnumStatus]{Unknown: UnknownEnumDefault, Default: testEnumStatusUnknown},
+			src:            "DELETED",
+			expected:       testEnumStatusUnknown,
+			expectedString: "UNKNOWN",
+		},
+		{
+			name:           "Passthrough",
+			options:        EnumOptions[testEnumStatus]{Unknown: UnknownEnumPassthrough},
+			src:            "7",
+			expected:       7,
+			expectedString: "7",
+		},
+		{
+			name:    "Passthrough not a number",
+			options: EnumOptions[testEnumStatus]{Unknown: UnknownEnumPassthrough},
+			src:     "DELETED",
+			err:     ErrUnknownEnum,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapper := NewMapper()
+			err := RegisterEnumWithOptions(mapper, testEnumStatusNames, test.options)
+			assert.Nil(t, err, "RegisterEnumWithOptions returned an error")
+
+			account := testEnumAccount{}
+			err = mapper.Map(testEnumAccountDTO{Status: test.src}, &account)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err, "Map returned an error")
+			assert.Equal(t, test.expected, account.Status)
+
+			dto := testEnumAccountDTO{}
+			err = mapper.Map(account, &dto)
+			assert.Nil(t, err, "Map returned an error")
+			assert.Equal(t, test.expectedString, dto.Status)
+		})
+	}
+}
+
+func TestRegisterEnumErrors(t *testing.T) {
+	mapper := NewMapper()
+	err := RegisterEnumWithOptions(mapper, map[testEnumStatus]string{1: "A", 2: "a"}, EnumOptions[testEnumStatus]{CaseInsensitive: true})
+	assert.Equal(t, fmt.Errorf("duplicate enum name %q", "a"), err)
+
+	err = RegisterEnumWithOptions(mapper, map[testEnumStatus]string{1: "A"}, EnumOptions[testEnumStatus]{Unknown: UnknownEnumDefault})
+	assert.Equal(t, fmt.Errorf("default value %v has no name", 0), err)
+}
+
+type testEnumLevel uint8
+
+func (l testEnumLevel) String() string {
+	return [...]string{"low", "high"}[l]
+}
+
+func (l *testEnumLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 0
+	case "high":
+		*l = 1
+	default:
+		return fmt.Errorf("unknown level %s", text)
+	}
+	return nil
+}
+
+func TestMapStringerAndTextUnmarshaler(t *testing.T) {
+	type Alert struct {
+		Level testEnumLevel
+		IP    net.IP
+	}
+	type AlertDTO struct {
+		Level string
+		IP    string
+	}
+
+	mapper := NewMapper()
+	dto := AlertDTO{}
+	err := mapper.Map(Alert{Level: 1}, &dto)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, "high", dto.Level)
+
+	alert := Alert{}
+	err = mapper.Map(AlertDTO{Level: "high", IP: "10.0.0.1"}, &alert)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, Alert{Level: 1, IP: net.ParseIP("10.0.0.1")}, alert)
+
+	err = mapper.Map(AlertDTO{Level: "medium"}, &alert)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
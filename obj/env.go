@@ -0,0 +1,198 @@
+package obj
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// ErrEnvMissing returned when a required environment variable is not set.
+var ErrEnvMissing error = fmt.Errorf("missing environment variable")
+
+// DefaultEnvSeparator is used to split environment variables into slices.
+const DefaultEnvSeparator = ","
+
+// EnvError describes a single environment variable that couldn't be bound.
+type EnvError struct {
+	// Name of the environment variable
+	Name string
+
+	// Err is the reason why the variable couldn't be bound
+	Err error
+}
+
+func (e *EnvError) Error() string {
+	return e.Name + ": " + e.Err.Error()
+}
+
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+// EnvOption customizes the behavior of BindEnv.
+type EnvOption func(*envOptions)
+
+type envOptions struct {
+	lookup    func(key string) (string, bool)
+	separator string
+}
+
+// WithEnvLookup replaces os.LookupEnv as the source of environment variables.
+func WithEnvLookup(lookup func(key string) (string, bool)) EnvOption {
+	return func(o *envOptions) {
+		o.lookup = lookup
+	}
+}
+
+// WithEnvSeparator changes the separator used to split variables into slices.
+// Defaults to [DefaultEnvSeparator].
+func WithEnvSeparator(separator string) EnvOption {
+	return func(o *envOptions) {
+		o.separator = separator
+	}
+}
+
+// BindEnv populates the struct pointed to by dst from environment variables.
+// The variable name of a field is its name in upper snake case joined to the
+// names of its parents and prefix, e.g. PREFIX_DB_HOST for dst.DB.Host.
+// Fields are configured with the following tags:
+//
+//	env:"NAME"          overrides the name of the field
+//	env:"-"             skips the field
+//	env:",required"     fails when the variable is not set
+//	default:"value"     value used when the variable is not set
+//
+// Nil pointers to structs are only allocated when one of their variables is
+// set. Errors for all missing and malformed variables, including the required
+// variables of structs left nil, are joined into the returned error, each
+// wrapped in an [EnvError].
+// Sample usage:
+//
+//	type Config struct {
+//		DB struct {
+//			Host    string `env:",required"`
+//			Port    int    `default:"5432"`
+//			Timeout time.Duration
+//		}
+//		Tags []string
+//	}
+//
+//	cfg := Config{}
+//	err := obj.BindEnv(&cfg, "APP") // reads APP_DB_HOST, APP_DB_PORT, APP_DB_TIMEOUT and APP_TAGS
+func BindEnv(dst any, prefix string, opts ...EnvOption) error {
+	options := envOptions{
+		lookup:    os.LookupEnv,
+		separator: DefaultEnvSeparator,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Pointer || dstValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dst must be a pointer to a struct")
+	}
+
+	var errs []error
+	bindEnvStruct(dstValue.Elem(), strings.TrimSuffix(prefix, "_"), &options, &errs)
+	return errors.Join(errs...)
+}
+
+// bindEnvStruct binds the fields of dst and returns true if any of their
+// variables was set.
+func bindEnvStruct(dst reflect.Value, prefix string, options *envOptions, errs *[]error) bool {
+	bound := false
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tagName, tagOpts, _ := strings.Cut(field.Tag.Get("env"), ",")
+		if tagName == "-" {
+			continue
+		}
+		name := tagName
+		if name == "" {
+			name = toUpperSnake(field.Name)
+		}
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		fieldValue := dst.Field(i)
+		if isEnvStruct(field.Type) {
+			if bindEnvStruct(fieldValue, name, options, errs) {
+				bound = true
+			}
+			continue
+		}
+		if field.Type.Kind() == reflect.Pointer && isEnvStruct(field.Type.Elem()) {
+			if !fieldValue.IsNil() {
+				if bindEnvStruct(fieldValue.Elem(), name, options, errs) {
+					bound = true
+				}
+				continue
+			}
+			// nil structs are only allocated when one of their variables is
+			// set, but their required variables are reported either way
+			newVal := reflect.New(field.Type.Elem())
+			if bindEnvStruct(newVal.Elem(), name, options, errs) {
+				fieldValue.Set(newVal)
+				bound = true
+			}
+			continue
+		}
+
+		value, ok := options.lookup(name)
+		if ok {
+			bound = true
+		} else {
+			value, ok = field.Tag.Lookup("default")
+		}
+		if !ok {
+			if hasTagOption(tagOpts, "required") {
+				*errs = append(*errs, &EnvError{Name: name, Err: ErrEnvMissing})
+			}
+			continue
+		}
+		err := setString(fieldValue, value, options.separator)
+		if err != nil {
+			*errs = append(*errs, &EnvError{Name: name, Err: err})
+		}
+	}
+	return bound
+}
+
+func isEnvStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isTextValue(t)
+}
+
+func hasTagOption(tagOpts string, option string) bool {
+	for _, opt := range strings.Split(tagOpts, ",") {
+		if strings.TrimSpace(opt) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// toUpperSnake converts a Go identifier to upper snake case, e.g. MaxConns to
+// MAX_CONNS and DBHost to DB_HOST.
+func toUpperSnake(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				sb.WriteByte('_')
+			}
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
@@ -0,0 +1,142 @@
+package obj
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEnvDB struct {
+	Host    string `env:",required"`
+	Port    int    `default:"5432"`
+	Timeout time.Duration
+}
+
+type testEnvConfig struct {
+	DB       testEnvDB
+	Replica  *testEnvDB
+	Tags     []string
+	MaxConns uint16
+	Debug    bool   `env:"VERBOSE"`
+	Skipped  string `env:"-"`
+	internal string
+}
+
+func testEnvLookup(env map[string]string) EnvOption {
+	return WithEnvLookup(func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	})
+}
+
+func TestBindEnv(t *testing.T) {
+	env := map[string]string{
+		"APP_DB_HOST":      "localhost",
+		"APP_DB_TIMEOUT":   "5s",
+		"APP_REPLICA_HOST": "replica",
+		"APP_TAGS":         "a, b,c",
+		"APP_MAX_CONNS":    "10",
+		"APP_VERBOSE":      "true",
+		"APP_SKIPPED":      "value",
+	}
+
+	cfg := testEnvConfig{}
+	err := BindEnv(&cfg, "APP", testEnvLookup(env))
+
+	assert.Nil(t, err, "BindEnv returned an error")
+	assert.Equal(t, testEnvConfig{
+		DB: testEnvDB{
+			Host:    "localhost",
+			Port:    5432,
+			Timeout: 5 * time.Second,
+		},
+		Replica:  &testEnvDB{Host: "replica", Port: 5432},
+		Tags:     []string{"a", "b", "c"},
+		MaxConns: 10,
+		Debug:    true,
+	}, cfg)
+}
+
+func TestBindEnvPointerStruct(t *testing.T) {
+	env := map[string]string{
+		"DB_HOST":         "primary",
+		"REPLICA_HOST":    "replica",
+		"REPLICA_TIMEOUT": "1m",
+	}
+
+	cfg := testEnvConfig{}
+	err := BindEnv(&cfg, "", testEnvLookup(env))
+
+	assert.Nil(t, err, "BindEnv returned an error")
+	assert.Equal(t, &testEnvDB{Host: "replica", Port: 5432, Timeout: time.Minute}, cfg.Replica)
+}
+
+func TestBindEnvNilPointerStruct(t *testing.T) {
+	cfg := struct {
+		DB    *testEnvDB
+		Cache *struct {
+			Size int `default:"10"`
+		}
+	}{}
+	err := BindEnv(&cfg, "APP", testEnvLookup(map[string]string{}))
+
+	assert.ErrorIs(t, err, ErrEnvMissing)
+	assert.Contains(t, err.Error(), "APP_DB_HOST")
+	assert.Nil(t, cfg.DB, "DB was allocated")
+	assert.Nil(t, cfg.Cache, "Cache was allocated")
+}
+
+func TestBindEnvSeparator(t *testing.T) {
+	cfg := struct {
+		Ports []int
+	}{}
+	err := BindEnv(&cfg, "APP_", testEnvLookup(map[string]string{"APP_PORTS": "80;443"}), WithEnvSeparator(";"))
+
+	assert.Nil(t, err, "BindEnv returned an error")
+	assert.Equal(t, []int{80, 443}, cfg.Ports)
+}
+
+func TestBindEnvErrors(t *testing.T) {
+	env := map[string]string{
+		"APP_DB_PORT":   "port",
+		"APP_MAX_CONNS": "100000",
+		"APP_TAGS":      "a",
+	}
+
+	cfg := testEnvConfig{}
+	err := BindEnv(&cfg, "APP", testEnvLookup(env))
+
+	assert.ErrorIs(t, err, ErrEnvMissing)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	var envErr *EnvError
+	assert.True(t, errors.As(err, &envErr))
+	assert.Contains(t, err.Error(), "APP_DB_HOST")
+	assert.Contains(t, err.Error(), "APP_DB_PORT")
+	assert.Contains(t, err.Error(), "APP_MAX_CONNS")
+	assert.NotContains(t, err.Error(), "APP_TAGS")
+	assert.Equal(t, []string{"a"}, cfg.Tags)
+}
+
+func TestBindEnvNotStructPointer(t *testing.T) {
+	cfg := testEnvConfig{}
+	err := BindEnv(cfg, "APP")
+	assert.Equal(t, fmt.Errorf("dst must be a pointer to a struct"), err)
+}
+
+func TestToUpperSnake(t *testing.T) {
+	tests := map[string]string{
+		"Host":     "HOST",
+		"DB":       "DB",
+		"MaxConns": "MAX_CONNS",
+		"DBHost":   "DB_HOST",
+		"HTTPPort": "HTTP_PORT",
+		"Port2":    "PORT2",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, toUpperSnake(name))
+	}
+}
@@ -0,0 +1,128 @@
+package obj
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// EqualOption customizes the behavior of Equal.
+type EqualOption func(*diffOptions)
+
+// WithEqualMapper compares different struct types using the field maps
+// configured in mapper, like [WithDiffMapper]. Without it, values of different
+// types are never equal.
+func WithEqualMapper(mapper *Mapper) EqualOption {
+	return func(o *diffOptions) {
+		o.mapper = mapper
+	}
+}
+
+// WithEqualIgnore ignores the values at the given paths and everything within
+// them, e.g. Customer.UpdatedAt or Items[*].ID where [*] matches any index or key.
+func WithEqualIgnore(paths ...string) EqualOption {
+	return func(o *diffOptions) {
+		o.ignorePaths = append(o.ignorePaths, paths...)
+	}
+}
+
+// WithEqualIgnoreFields ignores the struct fields with the given names at any depth.
+func WithEqualIgnoreFields(names ...string) EqualOption {
+	return func(o *diffOptions) {
+		if o.ignoreFields == nil {
+			o.ignoreFields = make(map[string]bool, len(names))
+		}
+		for _, name := range names {
+			o.ignoreFields[name] = true
+		}
+	}
+}
+
+// WithEqualNilAsEmpty treats nil slices and maps as equal to empty ones.
+func WithEqualNilAsEmpty() EqualOption {
+	return func(o *diffOptions) {
+		o.nilAsEmpty = true
+	}
+}
+
+// WithEqualTolerance treats floats as equal if they differ by at most tolerance.
+func WithEqualTolerance(tolerance float64) EqualOption {
+	return func(o *diffOptions) {
+		o.tolerance = tolerance
+	}
+}
+
+// Equal reports whether a and b are deeply equal. Unlike reflect.DeepEqual,
+// unexported fields, functions and channels are not compared, pointers are
+// compared by the values they point to and values with an Equal method, e.g.
+// time.Time, are compared with it. An error is returned if an ignored path
+// is invalid.
+// Sample usage:
+//
+//	equal, err := obj.Equal(dto, user, obj.WithEqualMapper(mapper), obj.WithEqualIgnoreFields("UpdatedAt"))
+func Equal(a any, b any, opts ...EqualOption) (bool, error) {
+	options := diffOptions{strict: true}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	d := differ{
+		options:  &options,
+		visiting: make(map[visitKey]bool),
+	}
+	for _, path := range options.ignorePaths {
+		segments, err := parsePath(path)
+		if err != nil {
+			return false, err
+		}
+		if len(segments) == 0 {
+			return false, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		d.ignored = append(d.ignored, segments)
+	}
+
+	err := d.diff("", reflect.ValueOf(a), reflect.ValueOf(b))
+	if err != nil {
+		// values that can't be compared are not equal
+		return false, nil
+	}
+	return len(d.changes) == 0, nil
+}
+
+// isIgnored reports whether path matches one of the ignored paths.
+func (d *differ) isIgnored(path string) bool {
+	if path == "" {
+		return false
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return false
+	}
+	for _, ignored := range d.ignored {
+		if matchPath(ignored, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPath(pattern []pathSegment, segments []pathSegment) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, segment := range pattern {
+		if segment.isField != segments[i].isField {
+			return false
+		}
+		if segment.isField && segment.field != segments[i].field {
+			return false
+		}
+		if !segment.isField && segment.key != "*" && segment.key != segments[i].key {
+			return false
+		}
+	}
+	return true
+}
+
+func isNilCollection(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Map
+}
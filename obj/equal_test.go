@@ -0,0 +1,163 @@
+package obj
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEqualOrder struct {
+	ID        int
+	Total     float64
+	Items     []testDiffItem
+	Labels    map[string]string
+	UpdatedAt time.Time
+	internal  string
+}
+
+func TestEqual(t *testing.T) {
+	now := time.Now()
+	tenth, fifth := 0.1, 0.2
+	tests := []struct {
+		name     string
+		a        any
+		b        any
+		opts     []EqualOption
+		expected bool
+	}{
+		{
+			name:     "Equal",
+			a:        testEqualOrder{ID: 1, Items: []testDiffItem{{1, 2}}, UpdatedAt: now, internal: "a"},
+			b:        &testEqualOrder{ID: 1, Items: []testDiffItem{{1, 2}}, UpdatedAt: now, internal: "b"},
+			expected: true,
+		},
+		{
+			name:     "Different",
+			a:        testEqualOrder{ID: 1, Items: []testDiffItem{{1, 2}}},
+			b:        testEqualOrder{ID: 1, Items: []testDiffItem{{1, 3}}},
+			expected: false,
+		},
+		{
+			name:     "Different types",
+			a:        testEqualOrder{},
+			b:        testDiffOrder{},
+			expected: false,
+		},
+		{
+			name:     "Nil and empty",
+			a:        testEqualOrder{Items: nil, Labels: map[string]string{}},
+			b:        testEqualOrder{Items: []testDiffItem{}, Labels: map[string]string{}},
+			expected: false,
+		},
+		{
+			name:     "Nil as empty",
+			a:        testEqualOrder{Items: nil, Labels: nil},
+			b:        testEqualOrder{Items: []testDiffItem{}, Labels: map[string]string{}},
+			opts:     []EqualOption{WithEqualNilAsEmpty()},
+			expected: true,
+		},
+		{
+			name:     "Float",
+			a:        testEqualOrder{Total: tenth + fifth},
+			b:        testEqualOrder{Total: 0.3},
+			expected: false,
+		},
+		{
+			name:     "Float tolerance",
+			a:        testEqualOrder{Total: tenth + fifth},
+			b:        testEqualOrder{Total: 0.3},
+			opts:     []EqualOption{WithEqualTolerance(1e-9)},
+			expected: true,
+		},
+		{
+			name:     "Ignore fields",
+			a:        testEqualOrder{ID: 1, Items: []testDiffItem{{1, 2}}, UpdatedAt: now},
+			b:        testEqualOrder{ID: 2, Items: []testDiffItem{{2, 2}}},
+			opts:     []EqualOption{WithEqualIgnoreFields("ID", "UpdatedAt")},
+			expected: true,
+		},
+		{
+			name:     "Ignore paths",
+			a:        testEqualOrder{ID: 1, Items: []testDiffItem{{1, 2}, {2, 2}}, Labels: map[string]string{"env": "dev"}},
+			b:        testEqualOrder{ID: 1, Items: []testDiffItem{{3, 2}, {4, 2}}, Labels: map[string]string{"env": "prod"}},
+			opts:     []EqualOption{WithEqualIgnore("Items[*].ID", "Labels[env]")},
+			expected: true,
+		},
+		{
+			name:     "Ignore paths not matching",
+			a:        testEqualOrder{ID: 1, Items: []testDiffItem{{1, 2}}},
+			b:        testEqualOrder{ID: 2, Items: []testDiffItem{{1, 2}}},
+			opts:     []EqualOption{WithEqualIgnore("Items[*].ID")},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			equal, err := Equal(test.a, test.b, test.opts...)
+			assert.Nil(t, err, "Equal returned an error")
+			assert.Equal(t, test.expected, equal)
+		})
+	}
+}
+
+func TestEqualWithMapper(t *testing.T) {
+	type UserDTO struct {
+		ID       int
+		FullName string
+		Password string
+	}
+	type User struct {
+		ID       int
+		Name     string
+		Password string
+	}
+
+	mapper := NewMapper()
+	err := ConfigureFieldMaps[UserDTO, User](mapper,
+		FieldMapConfig{Source: "FullName", Destination: "Name"},
+		FieldMapConfig{Destination: "Password", Ignore: true},
+	)
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+
+	equal, err := Equal(UserDTO{ID: 1, FullName: "John", Password: "a"}, User{ID: 1, Name: "John"}, WithEqualMapper(mapper))
+	assert.Nil(t, err, "Equal returned an error")
+	assert.True(t, equal)
+
+	equal, err = Equal(UserDTO{ID: 1, FullName: "John"}, User{ID: 1, Name: "Jane"}, WithEqualMapper(mapper))
+	assert.Nil(t, err, "Equal returned an error")
+	assert.False(t, equal)
+
+	equal, err = Equal(UserDTO{ID: 1, FullName: "John"}, User{ID: 1, Name: "John"})
+	assert.Nil(t, err, "Equal returned an error")
+	assert.False(t, equal, "different types must not be equal without a mapper")
+}
+
+func TestEqualNilEmbeddedPointer(t *testing.T) {
+	type Embedded struct {
+		X int
+	}
+	type Outer struct {
+		*Embedded
+	}
+	type Flat struct {
+		X int
+	}
+
+	equal, err := Equal(Outer{}, Flat{}, WithEqualMapper(NewMapper()))
+	assert.Nil(t, err, "Equal returned an error")
+	assert.False(t, equal)
+
+	equal, err = Equal(Outer{Embedded: &Embedded{}}, Flat{}, WithEqualMapper(NewMapper()))
+	assert.Nil(t, err, "Equal returned an error")
+	assert.True(t, equal)
+}
+
+func TestEqualInvalidPath(t *testing.T) {
+	_, err := Equal(testEqualOrder{}, testEqualOrder{}, WithEqualIgnore("Items[0"))
+	assert.ErrorIs(t, err, ErrInvalidPath)
+
+	_, err = Equal(testEqualOrder{}, testEqualOrder{}, WithEqualIgnore(""))
+	assert.ErrorIs(t, err, ErrInvalidPath)
+}
@@ -0,0 +1,301 @@
+package obj
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// HashOption customizes the behavior of Hash.
+type HashOption func(*hashOptions)
+
+type hashOptions struct {
+	ignoreFields map[string]bool
+	mapper       *Mapper
+	as           reflect.Type
+}
+
+// WithHashIgnoreFields ignores the struct fields with the given names at any depth.
+func WithHashIgnoreFields(names ...string) HashOption {
+	return func(o *hashOptions) {
+		if o.ignoreFields == nil {
+			o.ignoreFields = make(map[string]bool, len(names))
+		}
+		for _, name := range names {
+			o.ignoreFields[name] = true
+		}
+	}
+}
+
+// WithHashMapper hashes the value as the D it is mapped to by mapper: struct
+// fields are hashed by the names of the fields of D they are mapped to, and
+// fields ignored or without a destination are skipped. A DTO then hashes like
+// the domain value it maps to if their fields are equal.
+func WithHashMapper[D any](mapper *Mapper) HashOption {
+	return func(o *hashOptions) {
+		var zero D
+		o.mapper = mapper
+		o.as = reflect.TypeOf(zero)
+	}
+}
+
+// Hash returns a SHA-256 hash of the content of v that is stable across
+// processes: maps are hashed independently of their iteration order, struct
+// fields by name independently of their order, and pointers and interfaces by
+// the values they point to. Unexported fields, functions and channels are not
+// hashed. Structs implementing encoding.TextMarshaler, e.g. time.Time, are
+// hashed by their text.
+// Sample usage:
+//
+//	key, err := obj.Hash(event, obj.WithHashIgnoreFields("ReceivedAt"))
+func Hash(v any, opts ...HashOption) ([32]byte, error) {
+	options := hashOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	h := hasher{options: &options, visiting: make(map[visitKey]int)}
+	digest := sha256.New()
+	err := h.hash(digest, "", reflect.ValueOf(v), options.as)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	var sum [32]byte
+	digest.Sum(sum[:0])
+	return sum, nil
+}
+
+// tags written before each value so that different kinds don't collide,
+// e.g. "" and nil or [1] and 1.
+const (
+	hashNil byte = iota
+	hashBool
+	hashInt
+	hashUint
+	hashFloat
+	hashComplex
+	hashString
+	hashText
+	hashList
+	hashMap
+	hashStruct
+	hashCycle
+)
+
+type hasher struct {
+	options *hashOptions
+
+	// visiting contains the depth of the pointers, maps and slices being
+	// hashed, to detect cycles
+	visiting map[visitKey]int
+}
+
+// hash writes v to w. as is the type v is hashed as with WithHashMapper, nil otherwise.
+func (h *hasher) hash(w hash.Hash, path string, v reflect.Value, as reflect.Type) error {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && !v.IsNil() {
+		if v.Kind() == reflect.Pointer {
+			if !h.enter(w, v) {
+				return nil
+			}
+			defer h.leave(v)
+		}
+		v = v.Elem()
+	}
+	if as != nil {
+		as = indirectType(as)
+	}
+	if !v.IsValid() || ((v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil()) {
+		writeHash(w, hashNil)
+		return nil
+	}
+	if (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && !v.IsNil() {
+		if !h.enter(w, v) {
+			return nil
+		}
+		defer h.leave(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b := uint64(0)
+		if v.Bool() {
+			b = 1
+		}
+		writeHash(w, hashBool, b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeHash(w, hashInt, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeHash(w, hashUint, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeHash(w, hashFloat, floatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		writeHash(w, hashComplex, floatBits(real(v.Complex())), floatBits(imag(v.Complex())))
+	case reflect.String:
+		writeHashString(w, hashString, v.String())
+	case reflect.Slice, reflect.Array:
+		writeHash(w, hashList, uint64(v.Len()))
+		elemAs := collectionElem(as)
+		for i := 0; i < v.Len(); i++ {
+			err := h.hash(w, joinIndexPath(path, i), v.Index(i), elemAs)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		return h.hashMap(w, path, v, collectionElem(as))
+	case reflect.Struct:
+		if text, ok := interfaceOf(v, textMarshalerType); ok {
+			b, err := text.(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			writeHashString(w, hashText, string(b))
+			return nil
+		}
+		return h.hashStruct(w, path, v, as)
+	default:
+		// functions, channels and unsafe pointers aren't part of the content
+		writeHash(w, hashNil)
+	}
+	return nil
+}
+
+// enter marks the pointer, map or slice v as being hashed. If it already is,
+// the cycle is written as the distance to the value it refers back to and
+// false is returned.
+func (h *hasher) enter(w hash.Hash, v reflect.Value) bool {
+	key := visitKey{a: v.Pointer(), typ: v.Type()}
+	if depth, ok := h.visiting[key]; ok {
+		writeHash(w, hashCycle, uint64(len(h.visiting)-depth))
+		return false
+	}
+	h.visiting[key] = len(h.visiting)
+	return true
+}
+
+func (h *hasher) leave(v reflect.Value) {
+	delete(h.visiting, visitKey{a: v.Pointer(), typ: v.Type()})
+}
+
+// hashMap hashes each entry separately and writes the sorted entry hashes so
+// that the result doesn't depend on the iteration order.
+func (h *hasher) hashMap(w hash.Hash, path string, v reflect.Value, elemAs reflect.Type) error {
+	entries := make([][]byte, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		entry := sha256.New()
+		keyPath := joinKeyPath(path, iter.Key())
+		err := h.hash(entry, keyPath, iter.Key(), nil)
+		if err != nil {
+			return err
+		}
+		err = h.hash(entry, keyPath, iter.Value(), elemAs)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry.Sum(nil))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i], entries[j]) < 0
+	})
+
+	writeHash(w, hashMap, uint64(len(entries)))
+	for _, entry := range entries {
+		w.Write(entry)
+	}
+	return nil
+}
+
+// hashStruct writes the exported fields of v sorted by name. If as is another
+// struct type, the fields of as are written with the values of the fields of
+// v they are mapped from.
+func (h *hasher) hashStruct(w hash.Hash, path string, v reflect.Value, as reflect.Type) error {
+	type structField struct {
+		name  string
+		value reflect.Value
+		as    reflect.Type
+	}
+	var fields []structField
+	if as != nil && as.Kind() == reflect.Struct && as != v.Type() {
+		var fieldMaps map[string]*FieldMapConfig
+		if h.options.mapper != nil {
+			fieldMaps = h.options.mapper.fieldMapsOf(structMapKey{source: v.Type(), destination: as})
+		}
+		for i := 0; i < as.NumField(); i++ {
+			field := as.Field(i)
+			fieldMap := fieldMaps[field.Name]
+			if !field.IsExported() || h.options.ignoreFields[field.Name] || (fieldMap != nil && fieldMap.Ignore) {
+				continue
+			}
+			srcField, ok := v.Type().FieldByName(sourceFieldName(fieldMap, field.Name))
+			if !ok || !srcField.IsExported() {
+				continue
+			}
+			// invalid if promoted through a nil embedded pointer, hashed as nil
+			value, _ := fieldByIndex(v, srcField.Index, false)
+			fields = append(fields, structField{field.Name, value, field.Type})
+		}
+	} else {
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || h.options.ignoreFields[field.Name] {
+				continue
+			}
+			fields = append(fields, structField{field.Name, v.Field(i), nil})
+		}
+	}
+	slices.SortFunc(fields, func(a, b structField) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	writeHash(w, hashStruct, uint64(len(fields)))
+	for _, field := range fields {
+		writeHashString(w, hashString, field.name)
+		err := h.hash(w, joinFieldPath(path, field.name), field.value, field.as)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectionElem returns the element type of the collection type as, or nil.
+func collectionElem(as reflect.Type) reflect.Type {
+	if as == nil || !isCollectionKind(as.Kind()) {
+		return nil
+	}
+	return as.Elem()
+}
+
+// floatBits returns the bits of f with -0 and NaNs normalized.
+func floatBits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	if math.IsNaN(f) {
+		return math.Float64bits(math.NaN())
+	}
+	return math.Float64bits(f)
+}
+
+func writeHash(w hash.Hash, tag byte, values ...uint64) {
+	buf := make([]byte, 1, 1+8*len(values))
+	buf[0] = tag
+	for _, value := range values {
+		buf = binary.BigEndian.AppendUint64(buf, value)
+	}
+	w.Write(buf)
+}
+
+func writeHashString(w hash.Hash, tag byte, s string) {
+	writeHash(w, tag, uint64(len(s)))
+	w.Write([]byte(s))
+}
@@ -0,0 +1,182 @@
+package obj
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testHashEvent struct {
+	ID         int
+	Tags       map[string]int
+	Items      []*testDiffItem
+	Payload    any
+	ReceivedAt time.Time
+	internal   int
+}
+
+func TestHash(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		a        any
+		b        any
+		opts     []HashOption
+		expected bool
+	}{
+		{
+			name: "Equal",
+			a: testHashEvent{ID: 1, Tags: map[string]int{"a": 1, "b": 2, "c": 3}, Items: []*testDiffItem{{1, 2}},
+				Payload: "x", ReceivedAt: now, internal: 1},
+			b: &testHashEvent{ID: 1, Tags: map[string]int{"c": 3, "b": 2, "a": 1}, Items: []*testDiffItem{{1, 2}},
+				Payload: "x", ReceivedAt: now, internal: 2},
+			expected: true,
+		},
+		{
+			name:     "Different",
+			a:        testHashEvent{ID: 1, Items: []*testDiffItem{{1, 2}}},
+			b:        testHashEvent{ID: 1, Items: []*testDiffItem{{1, 3}}},
+			expected: false,
+		},
+		{
+			name:     "Different map",
+			a:        testHashEvent{Tags: map[string]int{"a": 1, "b": 2}},
+			b:        testHashEvent{Tags: map[string]int{"a": 2, "b": 1}},
+			expected: false,
+		},
+		{
+			name:     "Different kinds",
+			a:        testHashEvent{Payload: "1"},
+			b:        testHashEvent{Payload: 1},
+			expected: false,
+		},
+		{
+			name:     "Nil and empty string",
+			a:        testHashEvent{Payload: nil},
+			b:        testHashEvent{Payload: ""},
+			expected: false,
+		},
+		{
+			name:     "Ignore fields",
+			a:        testHashEvent{ID: 1, ReceivedAt: now},
+			b:        testHashEvent{ID: 1, ReceivedAt: now.Add(time.Hour)},
+			opts:     []HashOption{WithHashIgnoreFields("ReceivedAt")},
+			expected: true,
+		},
+		{
+			name:     "Field order",
+			a:        struct{ A, B int }{1, 2},
+			b:        struct{ B, A int }{2, 1},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := Hash(test.a, test.opts...)
+			assert.Nil(t, err, "Hash returned an error")
+			b, err := Hash(test.b, test.opts...)
+			assert.Nil(t, err, "Hash returned an error")
+			assert.Equal(t, test.expected, a == b)
+		})
+	}
+}
+
+func TestHashStable(t *testing.T) {
+	// the hash must not change across processes and versions
+	sum, err := Hash(testHashEvent{
+		ID:         1,
+		Tags:       map[string]int{"a": 1, "b": 2},
+		Items:      []*testDiffItem{{1, 2}},
+		Payload:    1.5,
+		ReceivedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Nil(t, err, "Hash returned an error")
+	assert.Equal(t, "a596a4874eebd7c5b9b2b2b0a68b34760407fb2864ef6a4331fb4ee64fbad339", hex.EncodeToString(sum[:]))
+}
+
+func TestHashWithMapper(t *testing.T) {
+	type AddressDTO struct {
+		Town string
+	}
+	type UserDTO struct {
+		ID       int
+		FullName string
+		Password string
+		Address  AddressDTO
+	}
+	type Address struct {
+		City string
+	}
+	type User struct {
+		ID       int
+		Name     string
+		Password string
+		Address  Address
+	}
+
+	mapper := NewMapper()
+	err := ConfigureFieldMaps[UserDTO, User](mapper,
+		FieldMapConfig{Source: "FullName", Destination: "Name"},
+		FieldMapConfig{Destination: "Password", Ignore: true},
+	)
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+	err = ConfigureFieldMaps[AddressDTO, Address](mapper, FieldMapConfig{Source: "Town", Destination: "City"})
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+
+	dto, err := Hash(UserDTO{ID: 1, FullName: "John", Password: "secret", Address: AddressDTO{Town: "Oslo"}}, WithHashMapper[User](mapper))
+	assert.Nil(t, err, "Hash returned an error")
+	user, err := Hash(User{ID: 1, Name: "John", Address: Address{City: "Oslo"}}, WithHashIgnoreFields("Password"))
+	assert.Nil(t, err, "Hash returned an error")
+	assert.Equal(t, user, dto)
+}
+
+func TestHashNilEmbeddedPointer(t *testing.T) {
+	type Embedded struct {
+		X int
+	}
+	type Outer struct {
+		*Embedded
+	}
+	type Flat struct {
+		X *int
+	}
+
+	outer, err := Hash(Outer{}, WithHashMapper[Flat](NewMapper()))
+	assert.Nil(t, err, "Hash returned an error")
+	flat, err := Hash(Flat{})
+	assert.Nil(t, err, "Hash returned an error")
+	assert.Equal(t, flat, outer)
+}
+
+func TestHashCycle(t *testing.T) {
+	type Node struct {
+		Value int
+		Next  *Node
+	}
+	a := &Node{Value: 1}
+	a.Next = a
+	b := &Node{Value: 1}
+	b.Next = b
+	c := &Node{Value: 1, Next: &Node{Value: 1}}
+	c.Next.Next = c
+
+	hashA, err := Hash(a)
+	assert.Nil(t, err, "Hash returned an error")
+	hashB, err := Hash(b)
+	assert.Nil(t, err, "Hash returned an error")
+	hashC, err := Hash(c)
+	assert.Nil(t, err, "Hash returned an error")
+	assert.Equal(t, hashA, hashB)
+	assert.NotEqual(t, hashA, hashC)
+
+	// values shared without a cycle are hashed every time they are reached
+	shared := &testDiffItem{ID: 1}
+	hashShared, err := Hash([]*testDiffItem{shared, shared})
+	assert.Nil(t, err, "Hash returned an error")
+	hashCopies, err := Hash([]*testDiffItem{{ID: 1}, {ID: 1}})
+	assert.Nil(t, err, "Hash returned an error")
+	assert.Equal(t, hashCopies, hashShared)
+}
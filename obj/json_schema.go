@@ -0,0 +1,199 @@
+package obj
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect generated by [Description.JSONSchema].
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// JSONSchema returns the JSON Schema (draft 2020-12) of the JSON encoding of
+// the described type. Fields are named after their json tags, fields that
+// aren't pointers and aren't omitempty are required, and named structs are
+// defined in $defs so that recursive types can refer to themselves. Enums
+// registered with the mapper are integers restricted to their values, as
+// encoded by json.Marshal: their names are only used when mapping to strings.
+// Sample usage:
+//
+//	desc, err := obj.Describe[User](mapper)
+//	...
+//	schema, err := desc.JSONSchema()
+func (d *Description) JSONSchema() ([]byte, error) {
+	g := schemaGenerator{
+		description: d,
+		names:       make(map[reflect.Type]string),
+		used:        make(map[string]bool),
+		defs:        make(map[string]any),
+	}
+	schema := g.schema(d.Root)
+	schema["$schema"] = JSONSchemaDraft
+	if len(g.defs) > 0 {
+		schema["$defs"] = g.defs
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+type schemaGenerator struct {
+	description *Description
+
+	// names contains the names of the structs in $defs
+	names map[reflect.Type]string
+	used  map[string]bool
+	defs  map[string]any
+}
+
+// schema returns the schema of t, which allows null if t is nullable.
+func (g *schemaGenerator) schema(t *TypeDescription) map[string]any {
+	schema := g.valueSchema(t)
+	if !t.Nullable {
+		return schema
+	}
+	if typ, ok := schema["type"].(string); ok {
+		schema["type"] = []string{typ, "null"}
+		return schema
+	}
+	if len(schema) == 0 {
+		return schema // anything, including null
+	}
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
+
+func (g *schemaGenerator) valueSchema(t *TypeDescription) map[string]any {
+	switch {
+	case t.Type == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.CustomJSON:
+		// encoded by its MarshalJSON method, even if it is also a TextMarshaler
+		return map[string]any{}
+	case t.Text:
+		return map[string]any{"type": "string"}
+	case t.Enum != nil:
+		// encoded as numbers by encoding/json, names are only used by the mapper
+		return map[string]any{"type": "integer", "enum": t.EnumValues}
+	}
+
+	switch t.Kind {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Kind == reflect.Slice && t.Elem.Kind == reflect.Uint8 && t.Elem.Enum == nil {
+			// encoded as base64 by encoding/json
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		schema := map[string]any{"type": "array", "items": g.schema(t.Elem)}
+		if t.Kind == reflect.Array {
+			schema["minItems"] = t.Type.Len()
+			schema["maxItems"] = t.Type.Len()
+		}
+		return schema
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem)}
+	case reflect.Struct:
+		if t.Type.Name() == "" {
+			return g.structSchema(t.Type)
+		}
+		return map[string]any{"$ref": "#/$defs/" + g.define(t.Type)}
+	}
+	// interfaces can hold anything
+	return map[string]any{}
+}
+
+// define adds the schema of the named struct t to $defs and returns its name.
+func (g *schemaGenerator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if g.used[name] {
+		name = t.String()
+	}
+	for i := 2; g.used[name]; i++ {
+		name = t.String() + strconv.Itoa(i)
+	}
+	// JSON pointer escaping
+	name = strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+	g.names[t] = name
+	g.used[name] = true
+	// named before its schema is generated so that recursive types refer to it
+	g.defs[name] = g.structSchema(t)
+	return name
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	g.addProperties(t, properties, &required)
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// addProperties adds the fields of t to properties, including the fields of
+// embedded structs which encoding/json promotes unless the outer struct has a
+// field with the same name.
+func (g *schemaGenerator) addProperties(t reflect.Type, properties map[string]any, required *[]string) {
+	desc := g.description.Structs[t]
+	if desc == nil {
+		return
+	}
+	var embedded []*TypeDescription
+	for _, field := range desc.Fields {
+		if field.JSONName == "" {
+			continue
+		}
+		if field.Embedded && field.Tag.Get("json") == "" && field.Type.Kind == reflect.Struct &&
+			!field.Type.Text && !field.Type.CustomJSON {
+			embedded = append(embedded, field.Type)
+			continue
+		}
+		properties[field.JSONName] = g.schema(field.Type)
+		if field.Required {
+			*required = append(*required, field.JSONName)
+		}
+	}
+
+	own := make(map[string]bool, len(properties))
+	for name := range properties {
+		own[name] = true
+	}
+	for _, embeddedType := range embedded {
+		promoted := make(map[string]any)
+		var promotedRequired []string
+		g.addProperties(embeddedType.Type, promoted, &promotedRequired)
+		if embeddedType.Nullable {
+			// encoding/json leaves out the fields of nil embedded pointers
+			promotedRequired = nil
+		}
+		for name, schema := range promoted {
+			if !own[name] {
+				properties[name] = schema
+			}
+		}
+		for _, name := range promotedRequired {
+			if !own[name] && !slices.Contains(*required, name) {
+				*required = append(*required, name)
+			}
+		}
+	}
+}
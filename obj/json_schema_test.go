@@ -0,0 +1,156 @@
+package obj
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONSchema(t *testing.T) {
+	desc, err := Describe[testDescribeUser](newTestDescribeMapper(t))
+	assert.Nil(t, err, "Describe returned an error")
+
+	schema, err := desc.JSONSchema()
+	assert.Nil(t, err, "JSONSchema returned an error")
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/testDescribeUser",
+		"$defs": {
+			"testDescribeUser": {
+				"type": "object",
+				"properties": {
+					"created_at": {"type": "string", "format": "date-time"},
+					"id": {"type": "integer"},
+					"name": {"type": "string"},
+					"status": {"type": "integer", "enum": [1, 2]},
+					"manager": {"anyOf": [{"$ref": "#/$defs/testDescribeUser"}, {"type": "null"}]},
+					"tags": {"type": ["object", "null"], "additionalProperties": {"type": "string"}}
+				},
+				"required": ["id", "status", "tags", "created_at"],
+				"additionalProperties": false
+			}
+		}
+	}`, string(schema))
+}
+
+func TestJSONSchemaTypes(t *testing.T) {
+	type Point struct {
+		X float64
+		Y float64
+	}
+	type Shape struct {
+		Points  [3]Point
+		Data    []byte
+		Count   *uint
+		Meta    any
+		Options struct {
+			Closed bool `json:"closed"`
+		} `json:"options"`
+		Extra json.RawMessage `json:"extra,omitempty"`
+	}
+
+	desc, err := Describe[Shape](NewMapper())
+	assert.Nil(t, err, "Describe returned an error")
+
+	schema, err := desc.JSONSchema()
+	assert.Nil(t, err, "JSONSchema returned an error")
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/Shape",
+		"$defs": {
+			"Point": {
+				"type": "object",
+				"properties": {"X": {"type": "number"}, "Y": {"type": "number"}},
+				"required": ["X", "Y"],
+				"additionalProperties": false
+			},
+			"Shape": {
+				"type": "object",
+				"properties": {
+					"Points": {"type": "array", "items": {"$ref": "#/$defs/Point"}, "minItems": 3, "maxItems": 3},
+					"Data": {"type": ["string", "null"], "contentEncoding": "base64"},
+					"Count": {"type": ["integer", "null"], "minimum": 0},
+					"Meta": {},
+					"options": {
+						"type": "object",
+						"properties": {"closed": {"type": "boolean"}},
+						"required": ["closed"],
+						"additionalProperties": false
+					},
+					"extra": {}
+				},
+				"required": ["Points", "Data", "Meta", "options"],
+				"additionalProperties": false
+			}
+		}
+	}`, string(schema))
+}
+
+func TestJSONSchemaNil(t *testing.T) {
+	type Base struct {
+		ID int
+	}
+	type Doc struct {
+		*Base
+		Items []string
+	}
+
+	encoded, err := json.Marshal(Doc{})
+	assert.Nil(t, err, "Marshal returned an error")
+	assert.Equal(t, `{"Items":null}`, string(encoded))
+
+	desc, err := Describe[Doc](NewMapper())
+	assert.Nil(t, err, "Describe returned an error")
+	schema, err := desc.JSONSchema()
+	assert.Nil(t, err, "JSONSchema returned an error")
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/Doc",
+		"$defs": {
+			"Doc": {
+				"type": "object",
+				"properties": {
+					"ID": {"type": "integer"},
+					"Items": {"type": ["array", "null"], "items": {"type": "string"}}
+				},
+				"required": ["Items"],
+				"additionalProperties": false
+			}
+		}
+	}`, string(schema))
+}
+
+type testSchemaLevel int
+
+func (l testSchemaLevel) MarshalText() ([]byte, error) {
+	return []byte([]string{"LOW", "HIGH"}[l]), nil
+}
+
+func TestJSONSchemaTextEnum(t *testing.T) {
+	type Alert struct {
+		Level testSchemaLevel `json:"level"`
+	}
+	mapper := NewMapper()
+	err := RegisterEnum(mapper, map[testSchemaLevel]string{0: "LOW", 1: "HIGH"})
+	assert.Nil(t, err, "RegisterEnum returned an error")
+
+	desc, err := Describe[Alert](mapper)
+	assert.Nil(t, err, "Describe returned an error")
+
+	schema, err := desc.JSONSchema()
+	assert.Nil(t, err, "JSONSchema returned an error")
+	// encoded by MarshalText, so the names of the enum are not known to be used
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/Alert",
+		"$defs": {
+			"Alert": {
+				"type": "object",
+				"properties": {"level": {"type": "string"}},
+				"required": ["level"],
+				"additionalProperties": false
+			}
+		}
+	}`, string(schema))
+}
@@ -0,0 +1,155 @@
+package obj
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ErrKeyCollision returned when different source keys are converted to the same destination key.
+var ErrKeyCollision error = fmt.Errorf("key collision")
+
+// KeyCollisionPolicy indicates what happens when different keys of a source
+// map are converted to the same destination key, e.g. "1" and "01" to 1.
+type KeyCollisionPolicy int
+
+const (
+	// KeyCollisionError fails with ErrKeyCollision.
+	KeyCollisionError KeyCollisionPolicy = 0
+
+	// KeyCollisionFirstWins keeps the entry whose source key sorts first.
+	KeyCollisionFirstWins KeyCollisionPolicy = 1
+
+	// KeyCollisionLastWins keeps the entry whose source key sorts last.
+	KeyCollisionLastWins KeyCollisionPolicy = 2
+)
+
+// MapKeyError describes a map key that couldn't be mapped.
+type MapKeyError struct {
+	// Path of the map, empty if it is the value passed to Map
+	Path string
+
+	// Key is the source key
+	Key any
+
+	// Err is the reason why the key couldn't be mapped
+	Err error
+}
+
+func (e *MapKeyError) Error() string {
+	key := fmt.Sprintf("key %#v", e.Key)
+	if e.Path != "" {
+		key = e.Path + ": " + key
+	}
+	return key + ": " + e.Err.Error()
+}
+
+func (e *MapKeyError) Unwrap() error {
+	return e.Err
+}
+
+// mapKey maps the key of entry, which is either its map key or its field named
+// name, to a value of type t. Keys that can't be mapped are converted between
+// strings and numbers, e.g. "42" to 42.
+func (m *Mapper) mapKey(state *mapState, entry collectionEntry, name string, t reflect.Type) (reflect.Value, error) {
+	srcKey := entry.key
+	if !srcKey.IsValid() {
+		var ok bool
+		srcKey, ok = keyField(entry.value, name)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%s: %w", joinFieldPath(entry.state.path(), name), ErrFieldNotFound)
+		}
+	}
+	dstKey := reflect.New(t).Elem()
+	err := m.mapValue(entry.state, srcKey, dstKey)
+	if errors.Is(err, ErrMismatchType) {
+		dstKey.SetZero()
+		err = convertKey(srcKey, dstKey)
+	}
+	if err != nil {
+		return reflect.Value{}, &MapKeyError{Path: state.path(), Key: interfaceOrNil(srcKey), Err: err}
+	}
+	return dstKey, nil
+}
+
+// convertKey converts src to dst between strings, booleans and numbers of any size.
+func convertKey(src reflect.Value, dst reflect.Value) error {
+	for src.Kind() == reflect.Pointer || src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return ErrMismatchType
+		}
+		src = src.Elem()
+	}
+	switch {
+	case src.Kind() == reflect.String && dst.CanInt() && dst.Type() != durationType:
+		// unlike setString, keys are always decimal so that "010" is 10
+		i, err := strconv.ParseInt(src.String(), 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%w: %q as %s", ErrInvalidValue, src.String(), dst.Type())
+		}
+		dst.SetInt(i)
+		return nil
+	case src.Kind() == reflect.String && dst.CanUint():
+		u, err := strconv.ParseUint(src.String(), 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%w: %q as %s", ErrInvalidValue, src.String(), dst.Type())
+		}
+		dst.SetUint(u)
+		return nil
+	case src.Kind() == reflect.String:
+		if !isScalarKind(dst.Kind()) && !dst.Addr().Type().Implements(textUnmarshalerType) {
+			return ErrMismatchType
+		}
+		return setString(dst, src.String(), DefaultEnvSeparator)
+	case dst.Kind() == reflect.String && isScalarKind(src.Kind()):
+		dst.SetString(formatScalar(src))
+		return nil
+	case isNumberKind(src.Kind()) && isNumberKind(dst.Kind()):
+		return convertNumber(src, dst)
+	}
+	return ErrMismatchType
+}
+
+// convertNumber converts src to dst if the number can be represented exactly, e.g. 2.0 to 2 but not 2.5.
+func convertNumber(src reflect.Value, dst reflect.Value) error {
+	converted := src.Convert(dst.Type())
+	if converted.Convert(src.Type()).Interface() != src.Interface() {
+		return fmt.Errorf("%w: %v can't be represented as %s", ErrInvalidValue, src.Interface(), dst.Type())
+	}
+	dst.Set(converted)
+	return nil
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isScalarKind(kind reflect.Kind) bool {
+	return kind == reflect.Bool || isNumberKind(kind)
+}
+
+func formatScalar(v reflect.Value) string {
+	switch {
+	case v.Kind() == reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case v.CanInt():
+		return strconv.FormatInt(v.Int(), 10)
+	case v.CanUint():
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+	return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+}
+
+func interfaceOrNil(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,120 @@
+package obj
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapKeyConversion(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      any
+		dst      any
+		expected any
+		err      error
+	}{
+		{
+			name:     "String to int",
+			src:      map[string]int{"1": 10, "2": 20},
+			dst:      &map[int]int{},
+			expected: &map[int]int{1: 10, 2: 20},
+		},
+		{
+			name:     "Int to string",
+			src:      map[int]int{1: 10, -2: 20},
+			dst:      &map[string]int{},
+			expected: &map[string]int{"1": 10, "-2": 20},
+		},
+		{
+			name:     "Int to int64",
+			src:      map[int]int{1: 10},
+			dst:      &map[int64]int{},
+			expected: &map[int64]int{1: 10},
+		},
+		{
+			name:     "String to text unmarshaler",
+			src:      map[string]int{"10.0.0.1": 1},
+			dst:      &map[netip.Addr]int{},
+			expected: &map[netip.Addr]int{netip.MustParseAddr("10.0.0.1"): 1},
+		},
+		{
+			name: "Overflow",
+			src:  map[int]int{300: 1},
+			dst:  &map[int8]int{},
+			err:  ErrInvalidValue,
+		},
+		{
+			name: "Fraction",
+			src:  map[float64]int{1.5: 1},
+			dst:  &map[int]int{},
+			err:  ErrInvalidValue,
+		},
+		{
+			name: "Collision",
+			src:  map[string]int{"1": 10, "01": 20},
+			dst:  &map[int]int{},
+			err:  ErrKeyCollision,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := NewMapper().Map(test.src, test.dst)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err, "Map returned an error")
+			assert.Equal(t, test.expected, test.dst)
+		})
+	}
+}
+
+func TestMapKeyCollisionPolicy(t *testing.T) {
+	src := map[string]int{"01": 1, "1": 2, "2": 3}
+	tests := []struct {
+		name     string
+		policy   KeyCollisionPolicy
+		expected map[int]int
+	}{
+		{
+			name:     "First wins",
+			policy:   KeyCollisionFirstWins,
+			expected: map[int]int{1: 1, 2: 3},
+		},
+		{
+			name:     "Last wins",
+			policy:   KeyCollisionLastWins,
+			expected: map[int]int{1: 2, 2: 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapper := NewMapperWithOptions(MapperOptions{KeyCollision: test.policy})
+			dst := map[int]int{}
+			err := mapper.Map(src, &dst)
+			assert.Nil(t, err, "Map returned an error")
+			assert.Equal(t, test.expected, dst)
+		})
+	}
+}
+
+func TestMapKeyError(t *testing.T) {
+	type Config struct {
+		Ports map[string]int
+	}
+	type ParsedConfig struct {
+		Ports map[int]int
+	}
+
+	dst := ParsedConfig{}
+	err := NewMapper().Map(Config{Ports: map[string]int{"80": 1, "http": 2}}, &dst)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+	assert.EqualError(t, err, `Ports: key "http": invalid value: "http" as int`)
+
+	err = NewMapper().Map(Config{Ports: map[string]int{"80": 1, "080": 2}}, &dst)
+	assert.EqualError(t, err, `Ports: key "80": key collision: 80 is mapped from another key`)
+}
@@ -0,0 +1,164 @@
+package obj
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// mapState is the state of mapping a single value within a call to Map. It
+// links to the state of its parent so that paths are only built when needed.
+type mapState struct {
+	parent    *mapState
+	fieldName string
+	elemIndex int
+	mapKey    reflect.Value
+
+	// mask contains the fields to be mapped, nil if all of them are mapped.
+	mask *fieldMask
+
+	// collection overrides how the value is mapped if it is a slice or a map.
+	collection *CollectionConfig
+
+	// weak allows loosely typed values to be decoded, see [Mapper.Decode].
+	weak bool
+
+	// ctx is the context passed to MapContext, nil for Map
+	ctx context.Context
+}
+
+func newMapState() *mapState {
+	return &mapState{elemIndex: -1}
+}
+
+// path returns the path of the value being mapped, e.g. Orders[1].Total.
+func (s *mapState) path() string {
+	if s.parent == nil {
+		return ""
+	}
+	path := s.parent.path()
+	switch {
+	case s.mapKey.IsValid():
+		return joinKeyPath(path, s.mapKey)
+	case s.elemIndex >= 0:
+		return joinIndexPath(path, s.elemIndex)
+	}
+	return joinFieldPath(path, s.fieldName)
+}
+
+// child returns the state of a value within the value being mapped.
+func (s *mapState) child() *mapState {
+	return &mapState{parent: s, elemIndex: -1, weak: s.weak, ctx: s.ctx}
+}
+
+// context returns the context of the mapping, which converters receive.
+func (s *mapState) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// err returns the error of the context if the mapping was cancelled.
+func (s *mapState) err() error {
+	if s.ctx == nil {
+		return nil
+	}
+	return s.ctx.Err()
+}
+
+// field returns the state of a struct field or false if it is excluded by the mask.
+func (s *mapState) field(field reflect.StructField) (*mapState, bool) {
+	child := s.child()
+	child.fieldName = field.Name
+	if s.mask != nil {
+		names := []string{field.Name}
+		if jsonName, ok := jsonFieldName(field); ok {
+			names = append(names, jsonName)
+		}
+		mask, ok := s.mask.child(names...)
+		if !ok {
+			return nil, false
+		}
+		child.mask = mask
+	}
+	return child, true
+}
+
+// index returns the state of a slice or array element or false if it is excluded by the mask.
+func (s *mapState) index(i int) (*mapState, bool) {
+	child := s.child()
+	child.elemIndex = i
+	if s.mask != nil {
+		mask, ok := s.mask.child(strconv.Itoa(i))
+		if !ok {
+			return nil, false
+		}
+		child.mask = mask
+	}
+	return child, true
+}
+
+// key returns the state of a map value or false if it is excluded by the mask.
+func (s *mapState) key(key reflect.Value) (*mapState, bool) {
+	child := s.child()
+	child.mapKey = key
+	if s.mask != nil {
+		mask, ok := s.mask.child(formatPathKey(key))
+		if !ok {
+			return nil, false
+		}
+		child.mask = mask
+	}
+	return child, true
+}
+
+// fieldMask is a tree of the fields to be mapped.
+type fieldMask struct {
+	children map[string]*fieldMask
+
+	// all indicates that everything within the field is mapped
+	all bool
+}
+
+// newFieldMask creates a field mask from dotted paths, e.g. address.city.
+func newFieldMask(paths []string) (*fieldMask, error) {
+	root := &fieldMask{children: make(map[string]*fieldMask)}
+	for _, path := range paths {
+		node := root
+		for _, name := range strings.Split(path, ".") {
+			if name == "" {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+			}
+			if node.all {
+				break
+			}
+			name = strings.ToLower(name)
+			child := node.children[name]
+			if child == nil {
+				child = &fieldMask{children: make(map[string]*fieldMask)}
+				node.children[name] = child
+			}
+			node = child
+		}
+		node.all = true
+		node.children = nil
+	}
+	return root, nil
+}
+
+// child returns the mask of the first of names found, nil if everything
+// within it is mapped, or false if none of names is in the mask.
+func (fm *fieldMask) child(names ...string) (*fieldMask, bool) {
+	for _, name := range append(names, "*") {
+		if child, ok := fm.children[strings.ToLower(name)]; ok {
+			if child.all {
+				return nil, true
+			}
+			return child, true
+		}
+	}
+	return nil, false
+}
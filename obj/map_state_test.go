@@ -0,0 +1,127 @@
+package obj
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMaskAddress struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
+
+type testMaskItem struct {
+	Name  string
+	Total int
+}
+
+type testMaskOrder struct {
+	ID       int
+	Address  testMaskAddress
+	Items    []testMaskItem
+	Labels   map[string]testMaskItem
+	getCalls *int
+}
+
+func (o testMaskOrder) GetSummary() string {
+	*o.getCalls++
+	return "expensive"
+}
+
+type testMaskOrderDTO struct {
+	ID      int
+	Address *testMaskAddress
+	Items   []testMaskItem
+	Labels  map[string]testMaskItem
+	Summary string
+}
+
+func testMaskSource(calls *int) testMaskOrder {
+	return testMaskOrder{
+		ID:       1,
+		Address:  testMaskAddress{City: "Manila", Street: "Main"},
+		Items:    []testMaskItem{{"a", 1}, {"b", 2}},
+		Labels:   map[string]testMaskItem{"x": {"x", 3}, "y": {"y", 4}},
+		getCalls: calls,
+	}
+}
+
+func TestMapFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     []string
+		expected testMaskOrderDTO
+		calls    int
+	}{
+		{
+			name:     "Top level",
+			mask:     []string{"id"},
+			expected: testMaskOrderDTO{ID: 1},
+		},
+		{
+			name:     "Nested field by JSON name",
+			mask:     []string{"address.city"},
+			expected: testMaskOrderDTO{Address: &testMaskAddress{City: "Manila"}},
+		},
+		{
+			name:     "Whole nested struct",
+			mask:     []string{"Address", "address.city"},
+			expected: testMaskOrderDTO{Address: &testMaskAddress{City: "Manila", Street: "Main"}},
+		},
+		{
+			name:     "Slice elements",
+			mask:     []string{"items.*.total"},
+			expected: testMaskOrderDTO{Items: []testMaskItem{{Total: 1}, {Total: 2}}},
+		},
+		{
+			name:     "Slice index",
+			mask:     []string{"items.1"},
+			expected: testMaskOrderDTO{Items: []testMaskItem{{"b", 2}}},
+		},
+		{
+			name:     "Map key",
+			mask:     []string{"labels.x.name"},
+			expected: testMaskOrderDTO{Labels: map[string]testMaskItem{"x": {Name: "x"}}},
+		},
+		{
+			name:     "Getter",
+			mask:     []string{"summary"},
+			expected: testMaskOrderDTO{Summary: "expensive"},
+			calls:    1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			dst := testMaskOrderDTO{}
+			err := NewMapper().MapFields(testMaskSource(&calls), &dst, test.mask)
+
+			assert.Nil(t, err, "MapFields returned an error")
+			assert.Equal(t, test.expected, dst)
+			assert.Equal(t, test.calls, calls, "getter calls")
+		})
+	}
+}
+
+func TestMapFieldsInvalidMask(t *testing.T) {
+	calls := 0
+	dst := testMaskOrderDTO{}
+	err := NewMapper().MapFields(testMaskSource(&calls), &dst, []string{"address..city"})
+	assert.ErrorIs(t, err, ErrInvalidPath)
+}
+
+func TestMapStatePath(t *testing.T) {
+	root := newMapState()
+	items, _ := root.field(reflectField(testMaskOrder{}, "Items"))
+	item, _ := items.index(2)
+	total, _ := item.field(reflectField(testMaskItem{}, "Total"))
+	assert.Equal(t, "Items[2].Total", total.path())
+}
+
+func reflectField(v any, name string) reflect.StructField {
+	field, _ := reflect.TypeOf(v).FieldByName(name)
+	return field
+}
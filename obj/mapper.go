@@ -1,6 +1,7 @@
 package obj
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
@@ -17,13 +18,48 @@ var ErrFieldNotFound error = fmt.Errorf("field not found")
 
 type Mapper struct {
 	cfg MapperConfig
+
+	// parent is the mapper this mapper is derived from
+	parent *Mapper
+}
+
+// MapperOptions contains settings of Mapper
+type MapperOptions struct {
+	// Redact masks the values of sensitive fields when mapping. See [ConfigureSensitiveFields].
+	Redact bool
+
+	// JSONBytes maps structs, maps and slices to and from []byte (including
+	// json.RawMessage) by encoding them as JSON.
+	JSONBytes bool
+
+	// ArrayLength indicates how arrays are mapped from arrays and slices of a
+	// different length. Defaults to ArrayErrorIfLonger.
+	ArrayLength ArrayLengthPolicy
+
+	// KeyCollision indicates what happens when different keys of a source map
+	// are converted to the same destination key. Defaults to KeyCollisionError.
+	KeyCollision KeyCollisionPolicy
+
+	// Defaults sets destination fields that are zero after mapping to the
+	// value of their default tag, like [ApplyDefaults]. Without it, zero
+	// values of the source are mapped as is.
+	Defaults bool
 }
 
 // NewMapper creates a new instance of Mapper
 func NewMapper() *Mapper {
+	return NewMapperWithOptions(MapperOptions{})
+}
+
+// NewMapperWithOptions creates a new instance of Mapper with the given options
+func NewMapperWithOptions(options MapperOptions) *Mapper {
 	return &Mapper{
 		cfg: MapperConfig{
-			fieldMaps: make(map[structMapKey]map[string]*FieldMapConfig),
+			fieldMaps:   make(map[structMapKey]map[string]*FieldMapConfig),
+			sensitive:   make(map[reflect.Type]map[string]RedactionMode),
+			enums:       make(map[reflect.Type]*enumConfig),
+			collections: make(map[reflect.Type]*CollectionConfig),
+			options:     options,
 		},
 	}
 }
@@ -72,16 +108,78 @@ func (m *Mapper) Map(src interface{}, dst interface{}) error {
 	if !dstValue.CanAddr() {
 		return ErrNotAddresable
 	}
-	return m.mapValue(srcValue, dstValue)
+	return m.mapValue(newMapState(), srcValue, dstValue)
+
+}
+
+// MapContext is like Map but stops with the error of ctx when it is cancelled,
+// which is checked between the elements of arrays, slices and maps. ctx is
+// also passed to converters like [FieldMapConfig.GetDestinationValueContext]
+// so that they can use request-scoped values, e.g. the locale of the user.
+// Sample usage:
+//
+//	ctx = context.WithValue(ctx, localeKey{}, "fil-PH")
+//	err := mapper.MapContext(ctx, orders, &dtos)
+func (m *Mapper) MapContext(ctx context.Context, src any, dst any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	srcValue := reflect.ValueOf(src)
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Type().Kind() == reflect.Pointer {
+		dstValue = dstValue.Elem()
+	}
+	if !dstValue.CanAddr() {
+		return ErrNotAddresable
+	}
+	state := newMapState()
+	state.ctx = ctx
+	return m.mapValue(state, srcValue, dstValue)
+}
 
+// MapFields is like Map but only populates the destination fields in mask.
+// Paths in the mask are made of field names separated by dots, matched
+// regardless of case against the names of the fields or their JSON names.
+// "*" matches any field, slice element or map key. Getters of fields that are
+// not in the mask are not called.
+// Sample usage:
+//
+//	err := mapper.MapFields(order, &dto, []string{"id", "address.city", "items.*.total"})
+func (m *Mapper) MapFields(src any, dst any, mask []string) error {
+	fieldMask, err := newFieldMask(mask)
+	if err != nil {
+		return err
+	}
+	srcValue := reflect.ValueOf(src)
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Type().Kind() == reflect.Pointer {
+		dstValue = dstValue.Elem()
+	}
+	if !dstValue.CanAddr() {
+		return ErrNotAddresable
+	}
+	state := newMapState()
+	state.mask = fieldMask
+	return m.mapValue(state, srcValue, dstValue)
 }
 
-func (m *Mapper) mapValue(src reflect.Value, dst reflect.Value) error {
+func (m *Mapper) mapValue(state *mapState, src reflect.Value, dst reflect.Value) error {
 	if !src.IsValid() || !dst.IsValid() {
 		return nil
 	}
 	if src.Type().Kind() == reflect.Pointer || src.Type().Kind() == reflect.Interface {
-		return m.mapValue(src.Elem(), dst)
+		return m.mapValue(state, src.Elem(), dst)
+	}
+	if handled, err := m.mapEnum(src, dst); handled {
+		return err
+	}
+	if handled, err := m.mapText(src, dst); handled {
+		return err
+	}
+	if state.weak {
+		if handled, err := m.mapWeak(state, src, dst); handled {
+			return err
+		}
 	}
 
 	switch dst.Type().Kind() {
@@ -167,13 +265,21 @@ func (m *Mapper) mapValue(src reflect.Value, dst reflect.Value) error {
 			return ErrMismatchType
 		}
 
-		if dst.Cap() < src.Len() {
-			return ErrInsufficientCapacity
+		n, err := m.arrayLength(state, src, dst)
+		if err != nil {
+			return err
 		}
 
-		for i := 0; i < src.Len(); i++ {
+		for i := 0; i < n; i++ {
+			if err := state.err(); err != nil {
+				return err
+			}
+			itemState, ok := state.index(i)
+			if !ok {
+				continue
+			}
 			dstItem := dst.Index(i)
-			err := m.mapValue(src.Index(i), dstItem)
+			err := m.mapValue(itemState, src.Index(i), dstItem)
 			if err != nil {
 				return err
 			}
@@ -187,63 +293,31 @@ func (m *Mapper) mapValue(src reflect.Value, dst reflect.Value) error {
 			if !dst.Elem().CanAddr() { // for structs with interface fields, value in it is always not addressable
 				return ErrNotAddresable
 			}
-			return m.mapValue(src, dst.Elem())
+			return m.mapValue(state, src, dst.Elem())
 		}
 
 		newVal := reflect.New(src.Type())
-		err := m.mapValue(src, newVal)
+		err := m.mapValue(state, src, newVal)
 		if err != nil {
 			return err
 		}
 		dst.Set(newVal.Elem())
 	case reflect.Map:
-		if src.Type().Kind() != reflect.Map {
-			return ErrMismatchType
-		}
-
-		if dst.IsNil() {
-			dst.Set(reflect.MakeMap(dst.Type()))
-		}
-
-		iter := src.MapRange()
-		for iter.Next() {
-			// map key
-			srcKey := iter.Key()
-			dstKey := reflect.New(dst.Type().Key())
-			err := m.mapValue(srcKey, dstKey)
-			if err != nil {
-				return err
-			}
-
-			// map value
-			srcVal := iter.Value()
-			dstVal := reflect.New(dst.Type().Elem())
-			err = m.mapValue(srcVal, dstVal)
-			if err != nil {
-				return err
-			}
-
-			dst.SetMapIndex(dstKey.Elem(), dstVal.Elem())
-		}
+		return m.mapMap(state, src, dst)
 	case reflect.Pointer:
 		if dst.IsNil() {
 			new := reflect.New(dst.Type().Elem())
+			if m.cfg.options.Defaults {
+				err := applyDefaults(new.Elem())
+				if err != nil {
+					return err
+				}
+			}
 			dst.Set(new)
 		}
-		return m.mapValue(src, dst.Elem())
+		return m.mapValue(state, src, dst.Elem())
 	case reflect.Slice:
-		if src.Type().Kind() != reflect.Array && src.Type().Kind() != reflect.Slice {
-			return ErrMismatchType
-		}
-		for i := 0; i < src.Len(); i++ {
-			dstElem := reflect.New(dst.Type().Elem())
-
-			err := m.mapValue(src.Index(i), dstElem.Elem())
-			if err != nil {
-				return err
-			}
-			dst.Set(reflect.Append(dst, dstElem.Elem()))
-		}
+		return m.mapSlice(state, src, dst)
 	case reflect.String:
 
 		if src.Type().Kind() != reflect.String {
@@ -254,11 +328,11 @@ func (m *Mapper) mapValue(src reflect.Value, dst reflect.Value) error {
 		if src.Type().Kind() != reflect.Struct {
 			return ErrMismatchType
 		}
-		err := m.mapStructFields(src, dst)
+		err := m.mapStructFields(state, src, dst)
 		if err != nil {
 			return err
 		}
-		err = m.mapStructSetters(src, dst)
+		err = m.mapStructSetters(state, src, dst)
 		if err != nil {
 			return err
 		}
@@ -269,20 +343,29 @@ func (m *Mapper) mapValue(src reflect.Value, dst reflect.Value) error {
 	return nil
 }
 
-func (m *Mapper) mapStructFields(src reflect.Value, dst reflect.Value) error {
+func (m *Mapper) mapStructFields(state *mapState, src reflect.Value, dst reflect.Value) error {
 	structMapKey := structMapKey{
 		source:      src.Type(),
 		destination: dst.Type(),
 	}
-	fieldMaps := m.cfg.fieldMaps[structMapKey]
+	fieldMaps := m.fieldMapsOf(structMapKey)
 	for i := 0; i < dst.NumField(); i++ {
+		if !dst.Type().Field(i).IsExported() {
+			continue
+		}
 		dstField := dst.Field(i)
 		fieldMap := fieldMaps[dst.Type().Field(i).Name]
 		srcFieldName := dst.Type().Field(i).Name
+		if fieldMap != nil && fieldMap.Ignore {
+			continue
+		}
+		fieldState, ok := state.field(dst.Type().Field(i))
+		if !ok {
+			continue
+		}
+		srcFieldName = sourceFieldName(fieldMap, srcFieldName)
 		if fieldMap != nil {
-			if len(fieldMap.Source) > 0 {
-				srcFieldName = fieldMap.Source
-			}
+			fieldState.collection = fieldMap.Collection
 		}
 		srcField := src.FieldByName(srcFieldName)
 		var err error
@@ -295,23 +378,32 @@ func (m *Mapper) mapStructFields(src reflect.Value, dst reflect.Value) error {
 			}
 			// AI generated code block end
 		}
-		if fieldMap == nil || fieldMap.GetDestinationValue == nil {
-			err = m.mapValue(srcField, dstField)
+		if !fieldMap.converts() {
+			err = m.mapValue(fieldState, srcField, dstField)
 		} else {
-			dstValue, err := fieldMap.GetDestinationValue(srcField.Interface())
+			dstValue, err := fieldMap.convert(fieldState.context(), src, srcField, dstField.Type())
 			if err != nil {
 				return err
 			}
-			dstField.Set(reflect.ValueOf(dstValue))
+			dstField.Set(dstValue)
 		}
 		if err != nil {
 			return err
 		}
+		err = m.applyFieldDefaults(dst.Type().Field(i), dstField, srcField.IsValid())
+		if err != nil {
+			return err
+		}
+		if m.cfg.options.Redact {
+			if mode, ok := m.sensitiveMode(src.Type(), srcFieldName, dst.Type(), dst.Type().Field(i).Name); ok {
+				redactValue(dstField, mode)
+			}
+		}
 	}
 	return nil
 }
 
-func (m *Mapper) mapStructSetters(src reflect.Value, dst reflect.Value) error {
+func (m *Mapper) mapStructSetters(state *mapState, src reflect.Value, dst reflect.Value) error {
 	// AI generated code block start
 	// Handle setter methods
 	dstType := dst.Addr().Type()
@@ -319,18 +411,24 @@ func (m *Mapper) mapStructSetters(src reflect.Value, dst reflect.Value) error {
 		source:      src.Type(),
 		destination: dst.Type(),
 	}
-	fieldMaps := m.cfg.fieldMaps[structMapKey]
+	fieldMaps := m.fieldMapsOf(structMapKey)
 	for i := 0; i < dstType.NumMethod(); i++ {
 		method := dstType.Method(i)
-		if method.Name[:3] == "Set" && method.Type.NumIn() == 2 && method.Type.NumOut() == 0 {
+		if isSetter(method) {
 			fieldName := method.Name[3:]
+			fieldState, ok := state.field(reflect.StructField{Name: fieldName})
+			if !ok {
+				continue
+			}
 			srcFieldName := fieldName
 			var fieldMap *FieldMapConfig
 			if fm, ok := fieldMaps[fieldName]; ok {
-				fieldMap = fm
-				if len(fieldMap.Source) > 0 {
-					srcFieldName = fieldMap.Source
+				if fm.Ignore {
+					continue
 				}
+				fieldMap = fm
+				srcFieldName = sourceFieldName(fieldMap, fieldName)
+				fieldState.collection = fieldMap.Collection
 			}
 			srcField := src.FieldByName(srcFieldName)
 			if !srcField.IsValid() {
@@ -344,19 +442,27 @@ func (m *Mapper) mapStructSetters(src reflect.Value, dst reflect.Value) error {
 			}
 			if srcField.IsValid() {
 				var paramValue reflect.Value
-				if fieldMap == nil || fieldMap.GetDestinationValue == nil {
+				if !fieldMap.converts() {
 					paramType := method.Type.In(1)
 					paramValue = reflect.New(paramType).Elem()
-					err := m.mapValue(srcField, paramValue)
+					err := m.mapValue(fieldState, srcField, paramValue)
 					if err != nil {
 						return err
 					}
 				} else {
-					dstValue, err := fieldMap.GetDestinationValue(srcField.Interface())
+					dstValue, err := fieldMap.convert(fieldState.context(), src, srcField, method.Type.In(1))
 					if err != nil {
 						return err
 					}
-					paramValue = reflect.ValueOf(dstValue)
+					paramValue = dstValue
+				}
+				if m.cfg.options.Redact {
+					if mode, ok := m.sensitiveMode(src.Type(), srcFieldName, dst.Type(), fieldName); ok {
+						paramCopy := reflect.New(paramValue.Type()).Elem()
+						paramCopy.Set(paramValue)
+						redactValue(paramCopy, mode)
+						paramValue = paramCopy
+					}
 				}
 				setterMethod := dst.Addr().MethodByName(method.Name)
 				setterMethod.Call([]reflect.Value{paramValue})
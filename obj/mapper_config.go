@@ -1,6 +1,7 @@
 package obj
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
@@ -11,6 +12,55 @@ type FieldMapConfig struct {
 	Source              string
 	Destination         string
 	GetDestinationValue func(source any) (any, error)
+
+	// GetDestinationValueContext is like GetDestinationValue but also receives
+	// the context passed to [Mapper.MapContext], or context.Background().
+	GetDestinationValueContext func(ctx context.Context, source any) (any, error)
+
+	// GetSourceValue converts the value of the destination back to the value of
+	// the source when mapping in reverse. See [ConfigureBidirectionalFieldMaps].
+	GetSourceValue func(destination any) (any, error)
+
+	// Ignore leaves the destination field untouched when mapping.
+	Ignore bool
+
+	// Collection overrides how the destination field is mapped if it is a
+	// slice or a map. See [ConfigureCollection].
+	Collection *CollectionConfig
+
+	// getValueFromSource converts the whole source to the value of the destination field. See [ForMember].
+	getValueFromSource func(ctx context.Context, source any) (any, error)
+}
+
+// converts reports whether the value of the destination field is returned by a converter.
+func (cfg *FieldMapConfig) converts() bool {
+	return cfg != nil && (cfg.GetDestinationValue != nil || cfg.GetDestinationValueContext != nil || cfg.getValueFromSource != nil)
+}
+
+// convert returns the value of the destination field of type t converted
+// from src, the source struct, or srcField, the value of the source field.
+func (cfg *FieldMapConfig) convert(ctx context.Context, src reflect.Value, srcField reflect.Value, t reflect.Type) (reflect.Value, error) {
+	var value any
+	var err error
+	switch {
+	case cfg.getValueFromSource != nil:
+		value, err = cfg.getValueFromSource(ctx, interfaceOrNil(src))
+	case cfg.GetDestinationValueContext != nil:
+		value, err = cfg.GetDestinationValueContext(ctx, interfaceOrNil(srcField))
+	default:
+		value, err = cfg.GetDestinationValue(interfaceOrNil(srcField))
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if value == nil {
+		return reflect.Zero(t), nil
+	}
+	v := reflect.ValueOf(value)
+	if !v.Type().AssignableTo(t) {
+		return reflect.Value{}, fmt.Errorf("%w: converter of %s returned %s instead of %s", ErrMismatchType, cfg.Destination, v.Type(), t)
+	}
+	return v, nil
 }
 
 type structMapKey struct {
@@ -19,7 +69,11 @@ type structMapKey struct {
 }
 
 type MapperConfig struct {
-	fieldMaps map[structMapKey]map[string]*FieldMapConfig
+	fieldMaps   map[structMapKey]map[string]*FieldMapConfig
+	sensitive   map[reflect.Type]map[string]RedactionMode
+	enums       map[reflect.Type]*enumConfig
+	collections map[reflect.Type]*CollectionConfig
+	options     MapperOptions
 }
 
 // ConfigureFieldMaps allows overriding of how fields are mapped for sourceT and destinationT
@@ -27,8 +81,11 @@ func ConfigureFieldMaps[sourceT any, destinationT any](mapper *Mapper,
 	fieldMapConfigs ...FieldMapConfig) error {
 	var zeroSource sourceT
 	var zeroDestination destinationT
-	sourceType := reflect.TypeOf(zeroSource)
-	destinationType := reflect.TypeOf(zeroDestination)
+	return configureFieldMaps(mapper, reflect.TypeOf(zeroSource), reflect.TypeOf(zeroDestination), fieldMapConfigs...)
+}
+
+func configureFieldMaps(mapper *Mapper, sourceType reflect.Type, destinationType reflect.Type,
+	fieldMapConfigs ...FieldMapConfig) error {
 	if sourceType.Kind() != reflect.Struct || destinationType.Kind() != reflect.Struct {
 		return fmt.Errorf("sourceT and destinationT must be structs")
 	}
@@ -47,9 +104,106 @@ func ConfigureFieldMaps[sourceT any, destinationT any](mapper *Mapper,
 		if cfg.Destination == "" {
 			return fmt.Errorf("destination field names must be provided")
 		}
+		if cfg.Collection != nil {
+			if field, ok := destinationType.FieldByName(cfg.Destination); ok {
+				err := cfg.Collection.validate(field.Type)
+				if err != nil {
+					return fmt.Errorf("%s: %w", cfg.Destination, err)
+				}
+			}
+		}
 
 		fieldMap[cfg.Destination] = &cfg
 	}
 	mapper.cfg.fieldMaps[structKey] = fieldMap
 	return nil
 }
+
+// ConfigureBidirectionalFieldMaps is like ConfigureFieldMaps but also configures
+// how destinationT is mapped back to sourceT by reversing fieldMapConfigs:
+// renames are inverted, GetSourceValue converts values back and fields ignored
+// in one direction are ignored in the other. Configurations that can't be
+// reversed, e.g. a GetDestinationValue without a GetSourceValue, are rejected
+// and nothing is configured.
+// Sample usage:
+//
+//	err := obj.ConfigureBidirectionalFieldMaps[UserDTO, User](mapper,
+//		obj.FieldMapConfig{Destination: "FullName", Source: "Name"},
+//		obj.FieldMapConfig{
+//			Destination:         "CreatedAt",
+//			GetDestinationValue: parseTime,
+//			GetSourceValue:      formatTime,
+//		},
+//	)
+func ConfigureBidirectionalFieldMaps[sourceT any, destinationT any](mapper *Mapper,
+	fieldMapConfigs ...FieldMapConfig) error {
+	var zeroSource sourceT
+	var zeroDestination destinationT
+	sourceType := reflect.TypeOf(zeroSource)
+	destinationType := reflect.TypeOf(zeroDestination)
+	if sourceType.Kind() != reflect.Struct || destinationType.Kind() != reflect.Struct {
+		return fmt.Errorf("sourceT and destinationT must be structs")
+	}
+
+	reversed, err := reverseFieldMaps(fieldMapConfigs)
+	if err != nil {
+		return err
+	}
+	for _, cfgs := range []struct {
+		destinationType reflect.Type
+		fieldMaps       []FieldMapConfig
+	}{{destinationType, fieldMapConfigs}, {sourceType, reversed}} {
+		for _, cfg := range cfgs.fieldMaps {
+			if cfg.Collection == nil {
+				continue
+			}
+			if field, ok := cfgs.destinationType.FieldByName(cfg.Destination); ok {
+				err := cfg.Collection.validate(field.Type)
+				if err != nil {
+					return fmt.Errorf("%s: %w", cfg.Destination, err)
+				}
+			}
+		}
+	}
+
+	err = configureFieldMaps(mapper, sourceType, destinationType, fieldMapConfigs...)
+	if err != nil {
+		return err
+	}
+	return configureFieldMaps(mapper, destinationType, sourceType, reversed...)
+}
+
+// reverseFieldMaps returns the configurations of mapping the destination back to the source.
+func reverseFieldMaps(fieldMapConfigs []FieldMapConfig) ([]FieldMapConfig, error) {
+	reversed := make([]FieldMapConfig, 0, len(fieldMapConfigs))
+	reversedFrom := make(map[string]string, len(fieldMapConfigs))
+	for _, cfg := range fieldMapConfigs {
+		if cfg.Destination == "" {
+			return nil, fmt.Errorf("destination field names must be provided")
+		}
+		if cfg.GetDestinationValueContext != nil {
+			return nil, fmt.Errorf("%s: GetDestinationValueContext can't be reversed", cfg.Destination)
+		}
+		if (cfg.GetDestinationValue == nil) != (cfg.GetSourceValue == nil) {
+			return nil, fmt.Errorf("%s: GetDestinationValue and GetSourceValue must be provided together to be reversed", cfg.Destination)
+		}
+		source := sourceFieldName(&cfg, cfg.Destination)
+		if other, ok := reversedFrom[source]; ok {
+			return nil, fmt.Errorf("%s: can't be reversed since %s is the source of both %s and %s", cfg.Destination, source, other, cfg.Destination)
+		}
+		reversedFrom[source] = cfg.Destination
+
+		reverse := FieldMapConfig{
+			Destination:         source,
+			GetDestinationValue: cfg.GetSourceValue,
+			GetSourceValue:      cfg.GetDestinationValue,
+			Ignore:              cfg.Ignore,
+			Collection:          cfg.Collection,
+		}
+		if source != cfg.Destination {
+			reverse.Source = cfg.Destination
+		}
+		reversed = append(reversed, reverse)
+	}
+	return reversed, nil
+}
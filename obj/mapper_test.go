@@ -1,9 +1,11 @@
 package obj
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -143,7 +145,7 @@ func TestMapArrayDst(t *testing.T) {
 			mapper := NewMapper()
 			err := mapper.Map(test.src, &test.dst)
 			if err != nil || test.err != nil {
-				assert.Equal(t, test.err, err, "Error not equal")
+				assert.ErrorIs(t, err, test.err, "Error not equal")
 				return
 			}
 			srcV := reflect.ValueOf(test.src)
@@ -342,9 +344,14 @@ func TestMapMapDst(t *testing.T) {
 		},
 		{
 			name: "Wrong key type",
-			src:  map[string]IntStruct{"1": {1}},
+			src:  map[bool]IntStruct{true: {1}},
 			err:  ErrMismatchType,
 		},
+		{
+			name: "Non-numeric key",
+			src:  map[string]IntStruct{"one": {1}},
+			err:  ErrInvalidValue,
+		},
 		{
 			name: "Wrong value type",
 			src:  map[int]int{1: 1},
@@ -357,7 +364,7 @@ func TestMapMapDst(t *testing.T) {
 			mapper := NewMapper()
 			err := mapper.Map(test.src, &test.dst)
 			if err != nil || test.err != nil {
-				assert.Equal(t, test.err, err, "Error not equal")
+				assert.ErrorIs(t, err, test.err, "Error not equal")
 				return
 			}
 			assert.Equal(t, test.src, test.dst)
@@ -433,6 +440,16 @@ func TestMapWithFieldMaps(t *testing.T) {
 			}},
 			expected: &WrappedAllTypes{testAllTypes{Int: 2}},
 		},
+		{
+			name: "Ignore field",
+			src:  testAllTypes{Int: 1, String: "test"},
+			dst:  &testAllTypes{Int: 3},
+			cfg: []FieldMapConfig{{
+				Destination: "Int",
+				Ignore:      true,
+			}},
+			expected: &testAllTypes{Int: 3, String: "test"},
+		},
 	}
 	// TODO: add error cases
 
@@ -491,6 +508,79 @@ func TestConfigureFieldMapsDestinationFieldEmpty(t *testing.T) {
 	assert.Equal(t, fmt.Errorf("destination field names must be provided"), err)
 }
 
+func TestConfigureBidirectionalFieldMaps(t *testing.T) {
+	type UserDTO struct {
+		Name    string
+		Age     string
+		Comment string
+	}
+	type User struct {
+		FullName string
+		Age      int
+		Comment  string
+	}
+
+	mapper := NewMapper()
+	err := ConfigureBidirectionalFieldMaps[UserDTO, User](mapper,
+		FieldMapConfig{Destination: "FullName", Source: "Name"},
+		FieldMapConfig{
+			Destination: "Age",
+			GetDestinationValue: func(source any) (any, error) {
+				return strconv.Atoi(source.(string))
+			},
+			GetSourceValue: func(destination any) (any, error) {
+				return strconv.Itoa(destination.(int)), nil
+			},
+		},
+		FieldMapConfig{Destination: "Comment", Ignore: true},
+	)
+	assert.Nil(t, err, "ConfigureBidirectionalFieldMaps returned an error")
+
+	user := User{}
+	err = mapper.Map(UserDTO{Name: "John", Age: "42", Comment: "hi"}, &user)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, User{FullName: "John", Age: 42}, user)
+
+	dto := UserDTO{}
+	err = mapper.Map(User{FullName: "Jane", Age: 7, Comment: "hello"}, &dto)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, UserDTO{Name: "Jane", Age: "7"}, dto)
+}
+
+func TestConfigureBidirectionalFieldMapsNotReversible(t *testing.T) {
+	convert := func(v any) (any, error) { return v, nil }
+	tests := []struct {
+		name string
+		cfg  []FieldMapConfig
+		err  string
+	}{
+		{
+			name: "One-way converter",
+			cfg:  []FieldMapConfig{{Destination: "Int", GetDestinationValue: convert}},
+			err:  "Int: GetDestinationValue and GetSourceValue must be provided together to be reversed",
+		},
+		{
+			name: "Reverse converter only",
+			cfg:  []FieldMapConfig{{Destination: "Int", GetSourceValue: convert}},
+			err:  "Int: GetDestinationValue and GetSourceValue must be provided together to be reversed",
+		},
+		{
+			name: "Same source",
+			cfg:  []FieldMapConfig{{Destination: "Int", Source: "Int8"}, {Destination: "Int16", Source: "Int8"}},
+			err:  "Int16: can't be reversed since Int8 is the source of both Int and Int16",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapper := NewMapper()
+			err := ConfigureBidirectionalFieldMaps[testAllTypes, testAllTypes](mapper, test.cfg...)
+			assert.EqualError(t, err, test.err)
+			assert.Empty(t, mapper.cfg.fieldMaps, "nothing must be configured")
+		})
+	}
+}
+
 // AI generated code start
 type testUserDTO struct {
 	ID             int
@@ -842,3 +932,79 @@ func TestMapWithNestedStructAndCustomFunction(t *testing.T) {
 }
 
 // AI generated code end
+
+type testContextKey struct{}
+
+func testCurrency(ctx context.Context) string {
+	if currency, ok := ctx.Value(testContextKey{}).(string); ok {
+		return currency
+	}
+	return "none"
+}
+
+func TestMapContext(t *testing.T) {
+	type PriceDTO struct {
+		Amount int
+	}
+	type Price struct {
+		Amount string
+		Label  string
+	}
+
+	mapper := NewMapper()
+	err := ConfigureFieldMaps[PriceDTO, Price](mapper, FieldMapConfig{
+		Destination: "Amount",
+		GetDestinationValueContext: func(ctx context.Context, source any) (any, error) {
+			return fmt.Sprintf("%s %d", testCurrency(ctx), source), nil
+		},
+	})
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+	err = ForMemberContext(mapper,
+		func(p *Price) *string { return &p.Label },
+		func(ctx context.Context, dto PriceDTO) (string, error) {
+			return "price in " + testCurrency(ctx), nil
+		},
+	)
+	assert.Nil(t, err, "ForMemberContext returned an error")
+
+	ctx := context.WithValue(context.Background(), testContextKey{}, "PHP")
+	prices := []Price{}
+	err = mapper.MapContext(ctx, []PriceDTO{{1}, {2}}, &prices)
+	assert.Nil(t, err, "MapContext returned an error")
+	assert.Equal(t, []Price{{"PHP 1", "price in PHP"}, {"PHP 2", "price in PHP"}}, prices)
+
+	price := Price{}
+	err = mapper.Map(PriceDTO{3}, &price)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, Price{"none 3", "price in none"}, price)
+}
+
+func TestMapContextCancelled(t *testing.T) {
+	type Item struct {
+		ID int
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	mapped := 0
+	mapper := NewMapper()
+	err := ForMember(mapper,
+		func(item *Item) *int { return &item.ID },
+		func(item Item) (int, error) {
+			mapped++
+			if mapped == 2 {
+				cancel()
+			}
+			return item.ID, nil
+		},
+	)
+	assert.Nil(t, err, "ForMember returned an error")
+
+	src := map[string][]Item{"a": {{1}, {2}, {3}, {4}}}
+	dst := map[string][]Item{}
+	err = mapper.MapContext(ctx, src, &dst)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, mapped, "mapping must stop once cancelled")
+
+	err = mapper.MapContext(ctx, src, &dst)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, mapped)
+}
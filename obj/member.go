@@ -0,0 +1,65 @@
+package obj
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// ForMember configures the field of D returned by selector to be set to the
+// value returned by resolve, which receives the whole source. Unlike
+// FieldMapConfig, the field and the types are checked at compile time.
+// Sample usage:
+//
+//	err := obj.ForMember(mapper,
+//		func(u *User) *string { return &u.FullName },
+//		func(dto UserDTO) (string, error) { return dto.FirstName + " " + dto.LastName, nil },
+//	)
+func ForMember[S any, D any, T any](mapper *Mapper, selector func(dst *D) *T, resolve func(src S) (T, error)) error {
+	return ForMemberContext(mapper, selector, func(_ context.Context, src S) (T, error) {
+		return resolve(src)
+	})
+}
+
+// ForMemberContext is like ForMember but resolve also receives the context
+// passed to [Mapper.MapContext], or context.Background().
+func ForMemberContext[S any, D any, T any](mapper *Mapper, selector func(dst *D) *T, resolve func(ctx context.Context, src S) (T, error)) error {
+	var zeroSource S
+	var dst D
+	sourceType := reflect.TypeOf(zeroSource)
+	destinationType := reflect.TypeOf(dst)
+	if sourceType == nil || destinationType == nil || sourceType.Kind() != reflect.Struct || destinationType.Kind() != reflect.Struct {
+		return fmt.Errorf("S and D must be structs")
+	}
+
+	field, err := selectedField(&dst, selector)
+	if err != nil {
+		return err
+	}
+	return configureFieldMaps(mapper, sourceType, destinationType, FieldMapConfig{
+		Destination: field.Name,
+		getValueFromSource: func(ctx context.Context, source any) (any, error) {
+			return resolve(ctx, source.(S))
+		},
+	})
+}
+
+// selectedField returns the field of dst that selector returns a pointer to.
+func selectedField[D any, T any](dst *D, selector func(dst *D) *T) (reflect.StructField, error) {
+	ptr := selector(dst)
+	fieldType := reflect.TypeOf((*T)(nil)).Elem()
+	start := uintptr(unsafe.Pointer(dst))
+	end := start + unsafe.Sizeof(*dst)
+	addr := uintptr(unsafe.Pointer(ptr))
+	if ptr != nil && addr >= start && addr < end {
+		t := reflect.TypeOf(dst).Elem()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Offset == addr-start && field.Type == fieldType && field.IsExported() {
+				return field, nil
+			}
+		}
+	}
+	return reflect.StructField{}, fmt.Errorf("selector must return a pointer to an exported field of %s", reflect.TypeOf(dst).Elem())
+}
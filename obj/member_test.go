@@ -0,0 +1,105 @@
+package obj
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMemberDTO struct {
+	FirstName string
+	LastName  string
+	Age       int
+}
+
+type testMember struct {
+	ID       int
+	FullName string
+	Age      int
+	Tags     []string
+	secret   string
+}
+
+func TestForMember(t *testing.T) {
+	mapper := NewMapper()
+	err := ForMember(mapper,
+		func(m *testMember) *string { return &m.FullName },
+		func(dto testMemberDTO) (string, error) { return dto.FirstName + " " + dto.LastName, nil },
+	)
+	assert.Nil(t, err, "ForMember returned an error")
+	err = ForMember(mapper,
+		func(m *testMember) *[]string { return &m.Tags },
+		func(dto testMemberDTO) ([]string, error) { return []string{fmt.Sprint(dto.Age)}, nil },
+	)
+	assert.Nil(t, err, "ForMember returned an error")
+
+	member := testMember{}
+	err = mapper.Map(testMemberDTO{FirstName: "John", LastName: "Doe", Age: 42}, &member)
+
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, testMember{FullName: "John Doe", Age: 42, Tags: []string{"42"}}, member)
+}
+
+func TestForMemberResolveError(t *testing.T) {
+	mapper := NewMapper()
+	resolveErr := fmt.Errorf("no name")
+	err := ForMember(mapper,
+		func(m *testMember) *string { return &m.FullName },
+		func(dto testMemberDTO) (string, error) { return "", resolveErr },
+	)
+	assert.Nil(t, err, "ForMember returned an error")
+
+	err = mapper.Map(testMemberDTO{}, &testMember{})
+	assert.Equal(t, resolveErr, err)
+}
+
+func TestForMemberInvalidSelector(t *testing.T) {
+	mapper := NewMapper()
+	other := ""
+	tests := []struct {
+		name     string
+		selector func(m *testMember) *string
+	}{
+		{
+			name:     "Unexported field",
+			selector: func(m *testMember) *string { return &m.secret },
+		},
+		{
+			name:     "Not a field",
+			selector: func(m *testMember) *string { return &other },
+		},
+		{
+			name:     "Nil",
+			selector: func(m *testMember) *string { return nil },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ForMember(mapper, test.selector, func(dto testMemberDTO) (string, error) { return "", nil })
+			assert.EqualError(t, err, "selector must return a pointer to an exported field of obj.testMember")
+		})
+	}
+
+	err := ForMember(mapper,
+		func(i *int) *int { return i },
+		func(dto testMemberDTO) (int, error) { return 0, nil },
+	)
+	assert.EqualError(t, err, "S and D must be structs")
+}
+
+func TestMapConverterWrongType(t *testing.T) {
+	mapper := NewMapper()
+	err := ConfigureFieldMaps[testMemberDTO, testMember](mapper, FieldMapConfig{
+		Destination: "Age",
+		GetDestinationValue: func(source any) (any, error) {
+			return "42", nil
+		},
+	})
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+
+	err = mapper.Map(testMemberDTO{Age: 42}, &testMember{})
+	assert.ErrorIs(t, err, ErrMismatchType)
+	assert.EqualError(t, err, "type mismatch: converter of Age returned string instead of int")
+}
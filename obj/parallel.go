@@ -0,0 +1,148 @@
+package obj
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+
+	"github.com/bryan-t/goeasy/async"
+)
+
+// ParallelOptions contains settings of MapSliceParallel.
+type ParallelOptions struct {
+	// Workers is the number of goroutines mapping elements. Defaults to runtime.GOMAXPROCS(0).
+	Workers int
+
+	// ChunkSize is the number of consecutive elements mapped by a goroutine at
+	// a time. Defaults to a size that gives each worker about 4 chunks.
+	ChunkSize int
+
+	// CollectErrors maps all the elements and returns all the errors, instead
+	// of stopping at the first one.
+	CollectErrors bool
+}
+
+// MapSliceParallel maps the elements of src to a new slice of D, splitting
+// the work across a pool of goroutines. The order of the elements is
+// preserved. Errors are prefixed with the index of the element, e.g. [42].
+// Mapping stops at the first error unless [ParallelOptions.CollectErrors] is
+// set, or when ctx is cancelled.
+// Sample usage:
+//
+//	dtos, err := obj.MapSliceParallel[User, UserDTO](ctx, mapper, users, obj.ParallelOptions{Workers: 8})
+func MapSliceParallel[S any, D any](ctx context.Context, mapper *Mapper, src []S, options ParallelOptions) ([]D, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if src == nil {
+		return nil, nil
+	}
+	dst := make([]D, len(src))
+	if len(src) == 0 {
+		return dst, nil
+	}
+
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunkSize := options.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = (len(src) + workers*4 - 1) / (workers * 4)
+	}
+	chunks := (len(src) + chunkSize - 1) / chunkSize
+
+	mapCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	state := newMapState()
+	state.ctx = mapCtx
+	errs := make([]error, len(src))
+	tasks := make([]*mapTask, 0, chunks)
+	for start := 0; start < len(src); start += chunkSize {
+		end := min(start+chunkSize, len(src))
+		tasks = append(tasks, newMapTask(mapCtx, func() {
+			for i := start; i < end; i++ {
+				if mapCtx.Err() != nil {
+					return
+				}
+				elemState, _ := state.index(i)
+				err := mapper.mapValue(elemState, reflect.ValueOf(&src[i]).Elem(), reflect.ValueOf(&dst[i]).Elem())
+				if err != nil {
+					errs[i] = fmt.Errorf("%s: %w", elemState.path(), err)
+					if !options.CollectErrors {
+						cancel()
+						return
+					}
+				}
+			}
+		}))
+	}
+
+	pool := async.NewWorkerPool(async.WorkerPoolOptions{Workers: min(workers, chunks), MaxQueuedTask: chunks})
+	err := pool.Start()
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		err = pool.AddTask(task)
+		if err != nil {
+			// the queued tasks return early once cancelled
+			cancel()
+			return nil, errors.Join(err, pool.Stop())
+		}
+	}
+	for _, task := range tasks {
+		task.Wait()
+	}
+	err = pool.Stop()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var mapErrs []error
+	for _, err := range errs {
+		if err == nil || errors.Is(err, context.Canceled) {
+			continue
+		}
+		if !options.CollectErrors {
+			// the first error by index, regardless of which was found first
+			return nil, err
+		}
+		mapErrs = append(mapErrs, err)
+	}
+	if len(mapErrs) > 0 {
+		return nil, errors.Join(mapErrs...)
+	}
+	return dst, nil
+}
+
+// mapTask runs a mapping function with the async package.
+type mapTask struct {
+	async.EasyTask
+	do   func()
+	done chan struct{}
+}
+
+func newMapTask(ctx context.Context, do func()) *mapTask {
+	return &mapTask{
+		EasyTask: async.EasyTask{TaskContext: ctx},
+		do:       do,
+		done:     make(chan struct{}),
+	}
+}
+
+func (t *mapTask) Do() {
+	defer close(t.done)
+	t.do()
+}
+
+// Wait blocks until the task is done. Unlike async.EasyWait, everything the
+// task wrote is visible once Wait returns.
+func (t *mapTask) Wait() {
+	<-t.done
+}
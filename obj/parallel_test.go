@@ -0,0 +1,115 @@
+package obj
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testParallelSrc struct {
+	ID   int
+	Name string
+}
+
+type testParallelDst struct {
+	ID   int
+	Name string
+}
+
+func testParallelSource(n int) []testParallelSrc {
+	src := make([]testParallelSrc, n)
+	for i := range src {
+		src[i] = testParallelSrc{ID: i, Name: fmt.Sprint("user", i)}
+	}
+	return src
+}
+
+func TestMapSliceParallel(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		options ParallelOptions
+	}{
+		{
+			name:    "Defaults",
+			n:       1000,
+			options: ParallelOptions{},
+		},
+		{
+			name:    "Uneven chunks",
+			n:       1001,
+			options: ParallelOptions{Workers: 3, ChunkSize: 7},
+		},
+		{
+			name:    "More workers than elements",
+			n:       2,
+			options: ParallelOptions{Workers: 8},
+		},
+		{
+			name: "Empty",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := testParallelSource(test.n)
+			dst, err := MapSliceParallel[testParallelSrc, testParallelDst](context.Background(), NewMapper(), src, test.options)
+
+			assert.Nil(t, err, "MapSliceParallel returned an error")
+			assert.Len(t, dst, test.n)
+			for i := range dst {
+				assert.Equal(t, testParallelDst{ID: i, Name: fmt.Sprint("user", i)}, dst[i])
+			}
+		})
+	}
+}
+
+func TestMapSliceParallelErrors(t *testing.T) {
+	var mapped atomic.Int32
+	mapper := NewMapper()
+	err := ConfigureFieldMaps[testParallelSrc, testParallelDst](mapper, FieldMapConfig{
+		Destination: "ID",
+		GetDestinationValue: func(source any) (any, error) {
+			mapped.Add(1)
+			if source.(int)%100 == 10 {
+				return nil, fmt.Errorf("invalid id %d", source)
+			}
+			return source, nil
+		},
+	})
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+
+	src := testParallelSource(1000)
+	_, err = MapSliceParallel[testParallelSrc, testParallelDst](context.Background(), mapper, src, ParallelOptions{Workers: 1, ChunkSize: 50})
+	assert.EqualError(t, err, "[10]: invalid id 10")
+	assert.Less(t, mapped.Load(), int32(1000), "mapping must stop at the first error")
+
+	_, err = MapSliceParallel[testParallelSrc, testParallelDst](context.Background(), mapper, src, ParallelOptions{Workers: 4, CollectErrors: true})
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 10)
+	assert.ErrorContains(t, err, "[910]: invalid id 910")
+}
+
+func TestMapSliceParallelCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mapper := NewMapper()
+	err := ConfigureFieldMaps[testParallelSrc, testParallelDst](mapper, FieldMapConfig{
+		Destination: "ID",
+		GetDestinationValue: func(source any) (any, error) {
+			if source.(int) == 5 {
+				cancel()
+			}
+			return source, nil
+		},
+	})
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+
+	dst, err := MapSliceParallel[testParallelSrc, testParallelDst](ctx, mapper, testParallelSource(100), ParallelOptions{Workers: 2})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, dst)
+
+	_, err = MapSliceParallel[testParallelSrc, testParallelDst](ctx, mapper, testParallelSource(100), ParallelOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,687 @@
+package obj
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// ErrInvalidPatch returned when a patch is malformed.
+var ErrInvalidPatch error = fmt.Errorf("invalid patch")
+
+// ErrPatchTestFailed returned when the value of a JSON Patch test operation doesn't match.
+var ErrPatchTestFailed error = fmt.Errorf("patch test failed")
+
+// JSON Patch operations
+const (
+	PatchOpAdd     = "add"
+	PatchOpRemove  = "remove"
+	PatchOpReplace = "replace"
+	PatchOpMove    = "move"
+	PatchOpCopy    = "copy"
+	PatchOpTest    = "test"
+)
+
+// PatchOperation is a single operation of a JSON Patch (RFC 6902).
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// PatchOption customizes the behavior of ApplyMergePatch and ApplyJSONPatch.
+type PatchOption func(*patcher)
+
+// WithPatchMapper converts patch values with the conversion rules of mapper,
+// e.g. the names of its registered enums. Defaults to a new mapper.
+func WithPatchMapper(mapper *Mapper) PatchOption {
+	return func(p *patcher) {
+		p.mapper = mapper
+	}
+}
+
+type patcher struct {
+	mapper *Mapper
+}
+
+func newPatcher(opts []PatchOption) *patcher {
+	p := &patcher{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.mapper == nil {
+		p.mapper = NewMapper()
+	}
+	return p
+}
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7396) to the value pointed to
+// by target. Fields are matched using their JSON names. Values are converted to
+// the type of the field like [Mapper.Decode] does, e.g. strings to registered
+// enums, times and other encoding.TextUnmarshaler implementations, except for
+// types implementing json.Unmarshaler and []byte which are decoded by
+// encoding/json. If the patch can't be applied, target is left unchanged.
+// Sample usage:
+//
+//	user := User{Name: "John", Email: "john@example.com"}
+//	err := obj.ApplyMergePatch(&user, []byte(`{"name": "Jane", "email": null}`))
+func ApplyMergePatch(target any, patch []byte, opts ...PatchOption) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.IsNil() {
+		return ErrNotAddresable
+	}
+	if !json.Valid(patch) {
+		return ErrInvalidPatch
+	}
+
+	p := newPatcher(opts)
+	patched := deepCopy(targetValue.Elem())
+	err := p.merge(patched, patch, "")
+	if err != nil {
+		return err
+	}
+	targetValue.Elem().Set(patched)
+	return nil
+}
+
+func (p *patcher) merge(dst reflect.Value, patch json.RawMessage, path string) error {
+	patch = bytes.TrimSpace(patch)
+	if bytes.Equal(patch, []byte("null")) {
+		dst.SetZero()
+		return nil
+	}
+	if len(patch) == 0 || patch[0] != '{' {
+		return p.decode(dst, patch, path)
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return p.merge(dst.Elem(), patch, path)
+	case reflect.Interface:
+		if dst.IsNil() || dst.Elem().Kind() != reflect.Map {
+			return p.decode(dst, patch, path)
+		}
+		elem := reflect.New(dst.Elem().Type()).Elem()
+		elem.Set(dst.Elem())
+		err := p.merge(elem, patch, path)
+		if err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.Struct, reflect.Map:
+	default:
+		return p.decode(dst, patch, path)
+	}
+	if dst.Kind() == reflect.Struct && isTextValue(dst.Type()) {
+		return p.decode(dst, patch, path)
+	}
+
+	members := map[string]json.RawMessage{}
+	err := json.Unmarshal(patch, &members)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, ErrInvalidPatch)
+	}
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		memberPath := path + "/" + escapePointerToken(name)
+		if dst.Kind() == reflect.Struct {
+			index, ok := jsonFieldIndex(dst.Type(), name)
+			if !ok {
+				return fmt.Errorf("%s: %w", memberPath, ErrFieldNotFound)
+			}
+			// nil embedded pointers are only allocated to set a field
+			null := bytes.Equal(bytes.TrimSpace(members[name]), []byte("null"))
+			field, ok := fieldByIndex(dst, index, !null)
+			if !ok {
+				if null {
+					continue
+				}
+				return fmt.Errorf("%s: %w", memberPath, ErrFieldNotFound)
+			}
+			err = p.merge(field, members[name], memberPath)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+		key, err := p.mapKey(dst, name, memberPath)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(bytes.TrimSpace(members[name]), []byte("null")) {
+			dst.SetMapIndex(key, reflect.Value{})
+			continue
+		}
+		val := reflect.New(dst.Type().Elem()).Elem()
+		if existing := dst.MapIndex(key); existing.IsValid() {
+			val.Set(existing)
+		}
+		err = p.merge(val, members[name], memberPath)
+		if err != nil {
+			return err
+		}
+		dst.SetMapIndex(key, val)
+	}
+	return nil
+}
+
+// ApplyJSONPatch applies the operations of a JSON Patch (RFC 6902) to the value
+// pointed to by target. Paths are JSON Pointers (RFC 6901) using the JSON names
+// of fields and values are converted like in [ApplyMergePatch]. Operations are
+// applied atomically: if one of them fails, including a failed test operation,
+// target is left unchanged.
+// Sample usage:
+//
+//	ops := []obj.PatchOperation{}
+//	err := json.Unmarshal(body, &ops)
+//	if err != nil {
+//		return err
+//	}
+//	err = obj.ApplyJSONPatch(&order, ops)
+func ApplyJSONPatch(target any, ops []PatchOperation, opts ...PatchOption) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.IsNil() {
+		return ErrNotAddresable
+	}
+
+	p := newPatcher(opts)
+	patched := deepCopy(targetValue.Elem())
+	for i, op := range ops {
+		err := p.apply(patched, op)
+		if err != nil {
+			return fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	targetValue.Elem().Set(patched)
+	return nil
+}
+
+// patchValue produces the value of an operation for the given type
+type patchValue func(t reflect.Type) (reflect.Value, error)
+
+func (p *patcher) apply(root reflect.Value, op PatchOperation) error {
+	tokens, err := parsePointer(op.Path)
+	if err != nil {
+		return err
+	}
+
+	rawValue := func(t reflect.Type) (reflect.Value, error) {
+		if len(op.Value) == 0 {
+			return reflect.Value{}, fmt.Errorf("%s: missing value: %w", op.Path, ErrInvalidPatch)
+		}
+		v := reflect.New(t).Elem()
+		err := p.decode(v, op.Value, op.Path)
+		return v, err
+	}
+
+	switch op.Op {
+	case PatchOpAdd:
+		return p.at(root, tokens, op.Path, func(container reflect.Value, token string) error {
+			return p.add(container, token, op.Path, rawValue)
+		})
+	case PatchOpRemove:
+		return p.at(root, tokens, op.Path, func(container reflect.Value, token string) error {
+			return p.remove(container, token, op.Path)
+		})
+	case PatchOpReplace:
+		return p.at(root, tokens, op.Path, func(container reflect.Value, token string) error {
+			return p.replace(container, token, op.Path, rawValue)
+		})
+	case PatchOpMove, PatchOpCopy:
+		fromTokens, err := parsePointer(op.From)
+		if err != nil {
+			return err
+		}
+		if op.Op == PatchOpMove && strings.HasPrefix(op.Path, op.From+"/") {
+			return fmt.Errorf("%s: can't move into a child of %s: %w", op.Path, op.From, ErrInvalidPatch)
+		}
+		var from reflect.Value
+		err = p.at(root, fromTokens, op.From, func(container reflect.Value, token string) error {
+			v, err := p.get(container, token, op.From, false)
+			if err != nil {
+				return err
+			}
+			from = deepCopy(v)
+			if op.Op == PatchOpMove {
+				return p.remove(container, token, op.From)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		fromValue := func(t reflect.Type) (reflect.Value, error) {
+			return p.convert(from, t, op.Path)
+		}
+		return p.at(root, tokens, op.Path, func(container reflect.Value, token string) error {
+			return p.add(container, token, op.Path, fromValue)
+		})
+	case PatchOpTest:
+		return p.at(root, tokens, op.Path, func(container reflect.Value, token string) error {
+			current, err := p.get(container, token, op.Path, false)
+			if err != nil {
+				return err
+			}
+			return patchTest(current, op.Value, op.Path)
+		})
+	}
+	return fmt.Errorf("unknown operation %q: %w", op.Op, ErrInvalidPatch)
+}
+
+// rootToken is passed to operations that target the whole document.
+const rootToken = "\x00root"
+
+// at navigates to the parent of the value referenced by tokens and calls
+// fn with the parent and the last token. Values that are not addressable on
+// the way, such as map values, are copied and stored back after fn succeeds.
+func (p *patcher) at(v reflect.Value, tokens []string, path string, fn func(container reflect.Value, token string) error) error {
+	if len(tokens) == 0 {
+		return fn(v, rootToken)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return fmt.Errorf("%s: %w", path, ErrFieldNotFound)
+		}
+		return p.at(v.Elem(), tokens, path, fn)
+	case reflect.Interface:
+		if v.IsNil() {
+			return fmt.Errorf("%s: %w", path, ErrFieldNotFound)
+		}
+		elem := deepCopy(v.Elem())
+		err := p.at(elem, tokens, path, fn)
+		if err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	if len(tokens) == 1 {
+		return fn(v, tokens[0])
+	}
+
+	child, err := p.get(v, tokens[0], path, false)
+	if err != nil {
+		return err
+	}
+	if v.Kind() != reflect.Map {
+		return p.at(child, tokens[1:], path, fn)
+	}
+	elem := deepCopy(child)
+	err = p.at(elem, tokens[1:], path, fn)
+	if err != nil {
+		return err
+	}
+	key, _ := p.mapKey(v, tokens[0], path)
+	v.SetMapIndex(key, elem)
+	return nil
+}
+
+// get returns the value of container referenced by token. Fields promoted
+// through nil embedded pointers are not found unless allocate is true, so
+// that only operations writing them allocate the pointers.
+func (p *patcher) get(container reflect.Value, token string, path string, allocate bool) (reflect.Value, error) {
+	if token == rootToken {
+		return container, nil
+	}
+	for container.Kind() == reflect.Pointer || container.Kind() == reflect.Interface {
+		if container.IsNil() {
+			return reflect.Value{}, fmt.Errorf("%s: %w", path, ErrFieldNotFound)
+		}
+		container = container.Elem()
+	}
+
+	switch container.Kind() {
+	case reflect.Struct:
+		field, ok := jsonFieldByName(container, token, allocate)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%s: %w", path, ErrFieldNotFound)
+		}
+		return field, nil
+	case reflect.Map:
+		key, err := p.mapKey(container, token, path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val := container.MapIndex(key)
+		if !val.IsValid() {
+			return reflect.Value{}, fmt.Errorf("%s: %w", path, ErrFieldNotFound)
+		}
+		return val, nil
+	case reflect.Slice, reflect.Array:
+		i, err := patchIndex(container, token, path, false)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return container.Index(i), nil
+	}
+	return reflect.Value{}, fmt.Errorf("%s: %w", path, ErrFieldNotFound)
+}
+
+func (p *patcher) add(container reflect.Value, token string, path string, value patchValue) error {
+	if token == rootToken {
+		v, err := value(container.Type())
+		if err != nil {
+			return err
+		}
+		container.Set(v)
+		return nil
+	}
+
+	switch container.Kind() {
+	case reflect.Map:
+		key, err := p.mapKey(container, token, path)
+		if err != nil {
+			return err
+		}
+		v, err := value(container.Type().Elem())
+		if err != nil {
+			return err
+		}
+		if container.IsNil() {
+			container.Set(reflect.MakeMap(container.Type()))
+		}
+		container.SetMapIndex(key, v)
+		return nil
+	case reflect.Slice:
+		i, err := patchIndex(container, token, path, true)
+		if err != nil {
+			return err
+		}
+		v, err := value(container.Type().Elem())
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(container.Type(), 0, container.Len()+1)
+		s = reflect.AppendSlice(s, container.Slice(0, i))
+		s = reflect.Append(s, v)
+		s = reflect.AppendSlice(s, container.Slice(i, container.Len()))
+		container.Set(s)
+		return nil
+	}
+	return p.replace(container, token, path, value)
+}
+
+func (p *patcher) replace(container reflect.Value, token string, path string, value patchValue) error {
+	if token != rootToken && container.Kind() == reflect.Map {
+		// replace requires the key to exist
+		_, err := p.get(container, token, path, false)
+		if err != nil {
+			return err
+		}
+		return p.add(container, token, path, value)
+	}
+
+	target, err := p.get(container, token, path, true)
+	if err != nil {
+		return err
+	}
+	v, err := value(target.Type())
+	if err != nil {
+		return err
+	}
+	target.Set(v)
+	return nil
+}
+
+func (p *patcher) remove(container reflect.Value, token string, path string) error {
+	if token == rootToken {
+		container.SetZero()
+		return nil
+	}
+
+	switch container.Kind() {
+	case reflect.Map:
+		key, err := p.mapKey(container, token, path)
+		if err != nil {
+			return err
+		}
+		if !container.MapIndex(key).IsValid() {
+			return fmt.Errorf("%s: %w", path, ErrFieldNotFound)
+		}
+		container.SetMapIndex(key, reflect.Value{})
+		return nil
+	case reflect.Slice:
+		i, err := patchIndex(container, token, path, false)
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(container.Type(), 0, container.Len()-1)
+		s = reflect.AppendSlice(s, container.Slice(0, i))
+		s = reflect.AppendSlice(s, container.Slice(i+1, container.Len()))
+		container.Set(s)
+		return nil
+	}
+
+	target, err := p.get(container, token, path, false)
+	if err != nil {
+		return err
+	}
+	target.SetZero()
+	return nil
+}
+
+func patchTest(current reflect.Value, expected json.RawMessage, path string) error {
+	if len(expected) == 0 {
+		return fmt.Errorf("%s: missing value: %w", path, ErrInvalidPatch)
+	}
+	currentJSON, err := json.Marshal(current.Interface())
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	var a, b any
+	err = json.Unmarshal(currentJSON, &a)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	err = json.Unmarshal(expected, &b)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, ErrInvalidPatch)
+	}
+	if !reflect.DeepEqual(a, b) {
+		return fmt.Errorf("%s: %w", path, ErrPatchTestFailed)
+	}
+	return nil
+}
+
+// convert converts the value of a move or copy operation to t.
+func (p *patcher) convert(v reflect.Value, t reflect.Type, path string) (reflect.Value, error) {
+	dst := reflect.New(t).Elem()
+	if v.Type().AssignableTo(t) {
+		dst.Set(v)
+		return dst, nil
+	}
+	state := newMapState()
+	state.weak = true
+	err := p.mapper.mapValue(state, v, dst)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%s: %w: %v", path, ErrMismatchType, err)
+	}
+	return dst, nil
+}
+
+// mapKey converts a member name or reference token to a key of the container
+// map the way the mapper converts map keys, e.g. "42" to 42.
+func (p *patcher) mapKey(container reflect.Value, token string, path string) (reflect.Value, error) {
+	entry := collectionEntry{state: newMapState(), key: reflect.ValueOf(token)}
+	key, err := p.mapper.mapKey(newMapState(), entry, "", container.Type().Key())
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%s: %w: %v", path, ErrMismatchType, err)
+	}
+	return key, nil
+}
+
+// patchIndex parses an array index. When insert is true, "-" and the length of
+// the slice are accepted to append.
+func patchIndex(container reflect.Value, token string, path string, insert bool) (int, error) {
+	if insert && token == "-" {
+		return container.Len(), nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (token != "0" && token[0] == '0') {
+		return 0, fmt.Errorf("%s: invalid index: %w", path, ErrInvalidPatch)
+	}
+	if i > container.Len() || (!insert && i == container.Len()) {
+		return 0, fmt.Errorf("%s: index out of range: %w", path, ErrFieldNotFound)
+	}
+	return i, nil
+}
+
+// decode decodes raw into dst, reporting type errors as ErrMismatchType.
+// Values are decoded into any and converted by the mapper, unless dst is
+// decoded by encoding/json in a way the mapper doesn't know about.
+func (p *patcher) decode(dst reflect.Value, raw json.RawMessage, path string) error {
+	v := reflect.New(dst.Type())
+	if implements(dst.Type(), jsonUnmarshalerType) || isBytes(dst.Type()) {
+		err := json.Unmarshal(raw, v.Interface())
+		if err != nil {
+			if _, ok := err.(*json.SyntaxError); ok {
+				return fmt.Errorf("%s: %w", path, ErrInvalidPatch)
+			}
+			return fmt.Errorf("%s: %w: %v", path, ErrMismatchType, err)
+		}
+		dst.Set(v.Elem())
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var decoded any
+	err := decoder.Decode(&decoded)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, ErrInvalidPatch)
+	}
+	state := newMapState()
+	state.weak = true
+	err = p.mapper.mapValue(state, reflect.ValueOf(jsonNumbers(decoded)), v.Elem())
+	if err != nil {
+		return fmt.Errorf("%s: %w: %v", path, ErrMismatchType, err)
+	}
+	dst.Set(v.Elem())
+	return nil
+}
+
+// jsonNumbers replaces the json.Number values in v by float64 like
+// json.Unmarshal does, or by int64 and uint64 for integers that float64 can't
+// represent exactly so that large IDs aren't rounded.
+func jsonNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil && int64(float64(i)) != i {
+			return i
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil && uint64(float64(u)) != u {
+			return u
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, value := range v {
+			v[key] = jsonNumbers(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = jsonNumbers(value)
+		}
+	}
+	return v
+}
+
+// parsePointer splits a JSON Pointer into unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("%s: %w", pointer, ErrInvalidPatch)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		token = strings.ReplaceAll(token, "~1", "/")
+		tokens[i] = strings.ReplaceAll(token, "~0", "~")
+	}
+	return tokens, nil
+}
+
+func escapePointerToken(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	return strings.ReplaceAll(token, "/", "~1")
+}
+
+// jsonFieldName returns the name of field in JSON or false if it's not encoded.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// jsonFieldByName returns the field of v that is encoded to JSON with the given
+// name, including fields promoted from embedded structs. Nil embedded pointers
+// on the way to the field are allocated if allocate is true, otherwise the
+// field is not found.
+func jsonFieldByName(v reflect.Value, name string, allocate bool) (reflect.Value, bool) {
+	index, ok := jsonFieldIndex(v.Type(), name)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return fieldByIndex(v, index, allocate)
+}
+
+// jsonFieldIndex returns the index of the field of t that is encoded to JSON
+// with the given name, for reflect.Value.FieldByIndex. Like encoding/json, an
+// exact match is preferred over a case-insensitive one.
+func jsonFieldIndex(t reflect.Type, name string) ([]int, bool) {
+	var fold []int
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && tagName == "" && indirectType(field.Type).Kind() == reflect.Struct {
+			if index, ok := jsonFieldIndex(indirectType(field.Type), name); ok {
+				return append([]int{i}, index...), true
+			}
+			continue
+		}
+		fieldName, ok := jsonFieldName(field)
+		if !ok || !field.IsExported() {
+			continue
+		}
+		if fieldName == name {
+			return []int{i}, true
+		}
+		if fold == nil && strings.EqualFold(fieldName, name) {
+			fold = []int{i}
+		}
+	}
+	return fold, fold != nil
+}
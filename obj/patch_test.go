@@ -0,0 +1,268 @@
+package obj
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPatchAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type testPatchUser struct {
+	ID       int               `json:"id"`
+	Name     string            `json:"name"`
+	Email    *string           `json:"email"`
+	Address  *testPatchAddress `json:"address"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]int    `json:"labels"`
+	Extra    any               `json:"extra"`
+	Internal string            `json:"-"`
+}
+
+func testPatchTarget() testPatchUser {
+	email := "john@example.com"
+	return testPatchUser{
+		ID:       1,
+		Name:     "John",
+		Email:    &email,
+		Address:  &testPatchAddress{City: "Manila", Zip: "1000"},
+		Tags:     []string{"a", "b"},
+		Labels:   map[string]int{"x": 1, "y": 2},
+		Extra:    map[string]any{"a": 1.0, "b": "c"},
+		Internal: "secret",
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	user := testPatchTarget()
+	err := ApplyMergePatch(&user, []byte(`{
+		"name": "Jane",
+		"email": null,
+		"address": {"city": "Cebu"},
+		"tags": ["c"],
+		"labels": {"x": null, "z": 3},
+		"extra": {"b": null, "d": true}
+	}`))
+
+	assert.Nil(t, err, "ApplyMergePatch returned an error")
+	assert.Equal(t, testPatchUser{
+		ID:       1,
+		Name:     "Jane",
+		Address:  &testPatchAddress{City: "Cebu", Zip: "1000"},
+		Tags:     []string{"c"},
+		Labels:   map[string]int{"y": 2, "z": 3},
+		Extra:    map[string]any{"a": 1.0, "d": true},
+		Internal: "secret",
+	}, user)
+}
+
+func TestApplyMergePatchAllocatesPointer(t *testing.T) {
+	user := testPatchUser{}
+	err := ApplyMergePatch(&user, []byte(`{"address": {"city": "Cebu"}}`))
+
+	assert.Nil(t, err, "ApplyMergePatch returned an error")
+	assert.Equal(t, &testPatchAddress{City: "Cebu"}, user.Address)
+}
+
+func TestApplyPatchWithMapper(t *testing.T) {
+	type Account struct {
+		ID       int64                  `json:"id"`
+		Status   testEnumStatus         `json:"status"`
+		Since    time.Time              `json:"since"`
+		Timeout  time.Duration          `json:"timeout"`
+		Statuses map[int]testEnumStatus `json:"statuses"`
+	}
+	mapper := NewMapper()
+	err := RegisterEnum(mapper, testEnumStatusNames)
+	assert.Nil(t, err, "RegisterEnum returned an error")
+
+	account := Account{}
+	err = ApplyMergePatch(&account, []byte(`{
+		"id": 9007199254740993,
+		"status": "ACTIVE",
+		"since": "2024-05-01T00:00:00Z",
+		"timeout": "1m",
+		"statuses": {"010": "SUSPENDED"}
+	}`), WithPatchMapper(mapper))
+	assert.Nil(t, err, "ApplyMergePatch returned an error")
+	assert.Equal(t, Account{
+		ID:       9007199254740993,
+		Status:   testEnumStatusActive,
+		Since:    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Timeout:  time.Minute,
+		Statuses: map[int]testEnumStatus{10: testEnumStatusSuspended},
+	}, account)
+
+	err = ApplyJSONPatch(&account, []PatchOperation{
+		{Op: PatchOpReplace, Path: "/status", Value: json.RawMessage(`"SUSPENDED"`)},
+		{Op: PatchOpCopy, From: "/status", Path: "/statuses/1"},
+	}, WithPatchMapper(mapper))
+	assert.Nil(t, err, "ApplyJSONPatch returned an error")
+	assert.Equal(t, testEnumStatusSuspended, account.Status)
+	assert.Equal(t, map[int]testEnumStatus{1: testEnumStatusSuspended, 10: testEnumStatusSuspended}, account.Statuses)
+
+	err = ApplyMergePatch(&account, []byte(`{"status": "DELETED"}`), WithPatchMapper(mapper))
+	assert.ErrorIs(t, err, ErrMismatchType)
+	err = ApplyMergePatch(&account, []byte(`{"status": "ACTIVE"}`))
+	assert.ErrorIs(t, err, ErrMismatchType, "enum isn't registered with the default mapper")
+	assert.Equal(t, testEnumStatusSuspended, account.Status, "target changed")
+}
+
+func TestApplyMergePatchErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		err   error
+	}{
+		{name: "Invalid JSON", patch: `{"name":`, err: ErrInvalidPatch},
+		{name: "Mismatch type", patch: `{"name": "Jane", "id": "2"}`, err: ErrMismatchType},
+		{name: "Mismatch nested type", patch: `{"address": {"city": 1}}`, err: ErrMismatchType},
+		{name: "Unknown field", patch: `{"name": "Jane", "unknown": 1}`, err: ErrFieldNotFound},
+		{name: "Ignored field", patch: `{"Internal": "x"}`, err: ErrFieldNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user := testPatchTarget()
+			err := ApplyMergePatch(&user, []byte(test.patch))
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, testPatchTarget(), user, "target changed")
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	ops := []PatchOperation{}
+	err := json.Unmarshal([]byte(`[
+		{"op": "test", "path": "/name", "value": "John"},
+		{"op": "replace", "path": "/name", "value": "Jane"},
+		{"op": "add", "path": "/tags/1", "value": "x"},
+		{"op": "add", "path": "/tags/-", "value": "z"},
+		{"op": "remove", "path": "/tags/0"},
+		{"op": "add", "path": "/labels/z", "value": 3},
+		{"op": "remove", "path": "/labels/x"},
+		{"op": "copy", "from": "/address/city", "path": "/address/zip"},
+		{"op": "move", "from": "/labels/y", "path": "/id"},
+		{"op": "remove", "path": "/email"},
+		{"op": "add", "path": "/extra/e~1f", "value": [1]},
+		{"op": "test", "path": "/extra", "value": {"a": 1, "b": "c", "e/f": [1]}}
+	]`), &ops)
+	assert.Nil(t, err, "Unmarshal returned an error")
+
+	user := testPatchTarget()
+	err = ApplyJSONPatch(&user, ops)
+
+	assert.Nil(t, err, "ApplyJSONPatch returned an error")
+	assert.Equal(t, testPatchUser{
+		ID:       2,
+		Name:     "Jane",
+		Address:  &testPatchAddress{City: "Manila", Zip: "Manila"},
+		Tags:     []string{"x", "b", "z"},
+		Labels:   map[string]int{"z": 3},
+		Extra:    map[string]any{"a": 1.0, "b": "c", "e/f": []any{1.0}},
+		Internal: "secret",
+	}, user)
+}
+
+func TestApplyJSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []PatchOperation
+		err  error
+	}{
+		{
+			name: "Test failed",
+			ops: []PatchOperation{
+				{Op: PatchOpReplace, Path: "/name", Value: json.RawMessage(`"Jane"`)},
+				{Op: PatchOpTest, Path: "/id", Value: json.RawMessage(`2`)},
+			},
+			err: ErrPatchTestFailed,
+		},
+		{
+			name: "Mismatch type",
+			ops:  []PatchOperation{{Op: PatchOpReplace, Path: "/tags/0", Value: json.RawMessage(`1`)}},
+			err:  ErrMismatchType,
+		},
+		{
+			name: "Index out of range",
+			ops:  []PatchOperation{{Op: PatchOpReplace, Path: "/tags/2", Value: json.RawMessage(`"c"`)}},
+			err:  ErrFieldNotFound,
+		},
+		{
+			name: "Missing map key",
+			ops:  []PatchOperation{{Op: PatchOpRemove, Path: "/labels/z"}},
+			err:  ErrFieldNotFound,
+		},
+		{
+			name: "Unknown field",
+			ops:  []PatchOperation{{Op: PatchOpAdd, Path: "/unknown", Value: json.RawMessage(`1`)}},
+			err:  ErrFieldNotFound,
+		},
+		{
+			name: "Invalid path",
+			ops:  []PatchOperation{{Op: PatchOpAdd, Path: "name", Value: json.RawMessage(`"Jane"`)}},
+			err:  ErrInvalidPatch,
+		},
+		{
+			name: "Unknown operation",
+			ops:  []PatchOperation{{Op: "merge", Path: "/name"}},
+			err:  ErrInvalidPatch,
+		},
+		{
+			name: "Move into child",
+			ops:  []PatchOperation{{Op: PatchOpMove, From: "/address", Path: "/address/city"}},
+			err:  ErrInvalidPatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user := testPatchTarget()
+			err := ApplyJSONPatch(&user, test.ops)
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, testPatchTarget(), user, "target changed")
+		})
+	}
+}
+
+func TestApplyPatchNilEmbeddedPointer(t *testing.T) {
+	type Meta struct {
+		Version int `json:"version"`
+	}
+	type Doc struct {
+		*Meta
+		Name string `json:"name"`
+	}
+
+	doc := Doc{Name: "a"}
+	err := ApplyJSONPatch(&doc, []PatchOperation{{Op: PatchOpTest, Path: "/name", Value: json.RawMessage(`"a"`)}})
+	assert.Nil(t, err, "ApplyJSONPatch returned an error")
+	assert.Nil(t, doc.Meta, "test operation allocated Meta")
+
+	err = ApplyJSONPatch(&doc, []PatchOperation{{Op: PatchOpTest, Path: "/version", Value: json.RawMessage(`0`)}})
+	assert.ErrorIs(t, err, ErrFieldNotFound)
+
+	err = ApplyMergePatch(&doc, []byte(`{"name": "b", "version": null}`))
+	assert.Nil(t, err, "ApplyMergePatch returned an error")
+	assert.Equal(t, Doc{Name: "b"}, doc)
+
+	err = ApplyJSONPatch(&doc, []PatchOperation{{Op: PatchOpReplace, Path: "/version", Value: json.RawMessage(`2`)}})
+	assert.Nil(t, err, "ApplyJSONPatch returned an error")
+	assert.Equal(t, Doc{Meta: &Meta{Version: 2}, Name: "b"}, doc)
+
+	doc = Doc{}
+	err = ApplyMergePatch(&doc, []byte(`{"version": 3}`))
+	assert.Nil(t, err, "ApplyMergePatch returned an error")
+	assert.Equal(t, Doc{Meta: &Meta{Version: 3}}, doc)
+}
+
+func TestApplyPatchNotPointer(t *testing.T) {
+	user := testPatchTarget()
+	assert.Equal(t, ErrNotAddresable, ApplyMergePatch(user, []byte(`{}`)))
+	assert.Equal(t, ErrNotAddresable, ApplyJSONPatch(user, nil))
+}
@@ -0,0 +1,132 @@
+package obj
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// joinFieldPath appends a struct field to path, e.g. Servers[2] and Timeout
+// becomes Servers[2].Timeout.
+func joinFieldPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// joinIndexPath appends a slice or array index to path.
+func joinIndexPath(path string, index int) string {
+	return path + "[" + strconv.Itoa(index) + "]"
+}
+
+// joinKeyPath appends a map key to path. String keys that can't be written
+// as is are quoted.
+func joinKeyPath(path string, key reflect.Value) string {
+	return path + "[" + formatPathKey(key) + "]"
+}
+
+func formatPathKey(key reflect.Value) string {
+	for key.Kind() == reflect.Interface || key.Kind() == reflect.Pointer {
+		if key.IsNil() {
+			return "nil"
+		}
+		key = key.Elem()
+	}
+	if key.Kind() != reflect.String {
+		return fmt.Sprint(key.Interface())
+	}
+	s := key.String()
+	if s == "" || strings.ContainsAny(s, `.[]"=`) || strings.TrimSpace(s) != s {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// ErrInvalidPath returned when a path can't be parsed.
+var ErrInvalidPath error = fmt.Errorf("invalid path")
+
+// ErrIndexOutOfRange returned when a path refers to an element past the end of a slice or an array.
+var ErrIndexOutOfRange error = fmt.Errorf("index out of range")
+
+// pathSegment is a single step of a path, either a field name or the content
+// of brackets for indices and map keys.
+type pathSegment struct {
+	field   string
+	key     string
+	isField bool
+}
+
+func (s pathSegment) String() string {
+	if s.isField {
+		return s.field
+	}
+	return "[" + s.key + "]"
+}
+
+// parsePath parses paths like Servers[2].Timeout, Labels[env] and Labels["a.b"].
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	i := 0
+	expectField := true
+	for i < len(path) {
+		switch {
+		case path[i] == '[':
+			end, key, err := parseBracket(path, i)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, pathSegment{key: key})
+			i = end
+			expectField = false
+		case path[i] == '.' && !expectField && i+1 < len(path):
+			i++
+			expectField = true
+		case expectField:
+			start := i
+			for i < len(path) && path[i] != '.' && path[i] != '[' && path[i] != ']' {
+				i++
+			}
+			if i == start {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+			}
+			segments = append(segments, pathSegment{field: path[start:i], isField: true})
+			expectField = false
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+	}
+	if expectField && len(segments) > 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+	return segments, nil
+}
+
+// parseBracket parses the bracket starting at path[start] and returns the
+// position after it and its unquoted content.
+func parseBracket(path string, start int) (int, string, error) {
+	if start+1 < len(path) && path[start+1] == '"' {
+		end := start + 2
+		for end < len(path) && path[end] != '"' {
+			if path[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end+1 >= len(path) || path[end+1] != ']' {
+			return 0, "", fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		key, err := strconv.Unquote(path[start+1 : end+1])
+		if err != nil {
+			return 0, "", fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		return end + 2, key, nil
+	}
+
+	end := strings.IndexByte(path[start:], ']')
+	if end < 0 {
+		return 0, "", fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+	return start + end + 1, path[start+1 : start+end], nil
+}
@@ -0,0 +1,260 @@
+package obj
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// SourceKind indicates where the value of a destination field comes from.
+type SourceKind string
+
+const (
+	// SourceField is a field of the source.
+	SourceField SourceKind = "field"
+
+	// SourceGetter is a getter of the source, e.g. GetName.
+	SourceGetter SourceKind = "getter"
+
+	// SourceConverter is a field or getter of the source converted with [FieldMapConfig.GetDestinationValue].
+	SourceConverter SourceKind = "converter"
+
+	// SourceIgnored is a destination field ignored with [FieldMapConfig.Ignore].
+	SourceIgnored SourceKind = "ignored"
+
+	// SourceNone is a destination field without a source. It is left
+	// untouched, or set to its default if it has a default tag.
+	SourceNone SourceKind = "none"
+
+	// SourceMissing is a setter without a source field or getter. Mapping
+	// fails with ErrFieldNotFound.
+	SourceMissing SourceKind = "missing"
+)
+
+// MappingPlan describes how a source type is mapped to a destination type.
+type MappingPlan struct {
+	Source      reflect.Type
+	Destination reflect.Type
+	Fields      []FieldPlan
+}
+
+// FieldPlan describes how a destination field is mapped.
+type FieldPlan struct {
+	// Destination is the name of the destination field or setter
+	Destination string
+
+	// Source is the name of the source field or getter, empty if Kind is SourceIgnored, SourceNone or SourceMissing
+	Source string
+
+	Kind SourceKind
+
+	// Configured indicates that the field is configured with a FieldMapConfig
+	Configured bool
+
+	// Setter indicates that the destination is set through a setter, e.g. SetName
+	Setter bool
+
+	// Collection indicates that the destination is a slice, array or map whose
+	// elements are mapped as described by Fields
+	Collection bool
+
+	// Fields describes how the fields of nested structs are mapped
+	Fields []FieldPlan
+}
+
+// Explain returns how the mapper maps S to D. See [Mapper.Plan].
+// Sample usage:
+//
+//	plan, err := obj.Explain[UserDTO, User](mapper)
+//	...
+//	fmt.Println(plan)
+func Explain[S any, D any](mapper *Mapper) (*MappingPlan, error) {
+	var zeroSource S
+	var zeroDestination D
+	return mapper.Plan(reflect.TypeOf(zeroSource), reflect.TypeOf(zeroDestination))
+}
+
+// Plan returns how the mapper maps srcType to dstType: where the value of each
+// destination field comes from, including the fields of nested structs.
+// Pointers are dereferenced, and both types must then be structs.
+func (m *Mapper) Plan(srcType reflect.Type, dstType reflect.Type) (*MappingPlan, error) {
+	if srcType == nil || dstType == nil {
+		return nil, fmt.Errorf("source and destination types must be structs")
+	}
+	srcType, dstType = indirectType(srcType), indirectType(dstType)
+	if srcType.Kind() != reflect.Struct || dstType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("source and destination types must be structs")
+	}
+	return &MappingPlan{
+		Source:      srcType,
+		Destination: dstType,
+		Fields:      m.planStruct(srcType, dstType, make(map[structMapKey]bool)),
+	}, nil
+}
+
+func (m *Mapper) planStruct(srcType reflect.Type, dstType reflect.Type, planning map[structMapKey]bool) []FieldPlan {
+	key := structMapKey{source: srcType, destination: dstType}
+	if planning[key] {
+		return nil // recursive types are described once
+	}
+	planning[key] = true
+	defer delete(planning, key)
+
+	fieldMaps := m.fieldMapsOf(key)
+	var fields []FieldPlan
+	planned := make(map[string]int)
+	for i := 0; i < dstType.NumField(); i++ {
+		dstField := dstType.Field(i)
+		if !dstField.IsExported() {
+			continue
+		}
+		field := m.planField(srcType, fieldMaps[dstField.Name], dstField.Name)
+		if field.Kind == SourceField || field.Kind == SourceGetter {
+			field.Collection, field.Fields = m.planNested(m.planSourceType(srcType, field), dstField.Type, planning)
+		}
+		planned[dstField.Name] = len(fields)
+		fields = append(fields, field)
+	}
+
+	ptrType := reflect.PointerTo(dstType)
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		method := ptrType.Method(i)
+		if !isSetter(method) {
+			continue
+		}
+		name := method.Name[3:]
+		field := m.planField(srcType, fieldMaps[name], name)
+		field.Setter = true
+		if field.Kind != SourceIgnored && !hasSourceMember(srcType, sourceFieldName(fieldMaps[name], name)) {
+			// unlike fields, setters without a source make Map fail
+			field.Kind = SourceMissing
+		}
+		if field.Kind == SourceField || field.Kind == SourceGetter {
+			field.Collection, field.Fields = m.planNested(m.planSourceType(srcType, field), method.Type.In(1), planning)
+		}
+		if j, ok := planned[name]; ok {
+			// the setter is called after the field is mapped
+			fields[j] = field
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// planField describes the source of the destination field named dstName.
+func (m *Mapper) planField(srcType reflect.Type, fieldMap *FieldMapConfig, dstName string) FieldPlan {
+	field := FieldPlan{Destination: dstName, Configured: fieldMap != nil}
+	if fieldMap != nil && fieldMap.Ignore {
+		field.Kind = SourceIgnored
+		return field
+	}
+	if fieldMap != nil && fieldMap.getValueFromSource != nil {
+		field.Kind = SourceConverter
+		return field
+	}
+	name := sourceFieldName(fieldMap, dstName)
+	if _, ok := srcType.FieldByName(name); ok {
+		field.Source, field.Kind = name, SourceField
+	} else if isGetter(srcType, "Get"+name) {
+		field.Source, field.Kind = "Get"+name, SourceGetter
+	} else {
+		field.Kind = SourceNone
+		return field
+	}
+	if fieldMap.converts() {
+		field.Kind = SourceConverter
+	}
+	return field
+}
+
+func (m *Mapper) planSourceType(srcType reflect.Type, field FieldPlan) reflect.Type {
+	if field.Kind == SourceGetter {
+		method, _ := srcType.MethodByName(field.Source)
+		return method.Type.Out(0)
+	}
+	srcField, _ := srcType.FieldByName(field.Source)
+	return srcField.Type
+}
+
+// planNested describes the fields of dstType if it is a struct, or a collection of structs, mapped from a struct.
+func (m *Mapper) planNested(srcType reflect.Type, dstType reflect.Type, planning map[structMapKey]bool) (bool, []FieldPlan) {
+	srcType, dstType = indirectType(srcType), indirectType(dstType)
+	collection := false
+	for isCollectionKind(srcType.Kind()) && isCollectionKind(dstType.Kind()) {
+		srcType, dstType = indirectType(srcType.Elem()), indirectType(dstType.Elem())
+		collection = true
+	}
+	if srcType.Kind() != reflect.Struct || dstType.Kind() != reflect.Struct || isTextValue(dstType) {
+		return false, nil
+	}
+	return collection, m.planStruct(srcType, dstType, planning)
+}
+
+func isCollectionKind(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+}
+
+// sourceFieldName returns the name of the source field, or of its getter
+// without the Get prefix, that a destination field is mapped from.
+func sourceFieldName(fieldMap *FieldMapConfig, dstName string) string {
+	if fieldMap != nil && len(fieldMap.Source) > 0 {
+		return fieldMap.Source
+	}
+	return dstName
+}
+
+// hasSourceMember reports whether srcType has a field named name or its getter.
+func hasSourceMember(srcType reflect.Type, name string) bool {
+	_, ok := srcType.FieldByName(name)
+	return ok || isGetter(srcType, "Get"+name)
+}
+
+func isGetter(t reflect.Type, name string) bool {
+	method, ok := t.MethodByName(name)
+	return ok && method.Type.NumIn() == 1 && method.Type.NumOut() == 1
+}
+
+func isSetter(method reflect.Method) bool {
+	return strings.HasPrefix(method.Name, "Set") && method.Type.NumIn() == 2 && method.Type.NumOut() == 0
+}
+
+// String describes the plan as a table of destination fields and their sources.
+func (p *MappingPlan) String() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "%s -> %s\n", p.Source, p.Destination)
+	w := tabwriter.NewWriter(sb, 0, 4, 2, ' ', 0)
+	writeFieldPlans(w, "", "", p.Fields)
+	w.Flush()
+	return sb.String()
+}
+
+func writeFieldPlans(w *tabwriter.Writer, dstPrefix string, srcPrefix string, fields []FieldPlan) {
+	for _, field := range fields {
+		dst := dstPrefix + field.Destination
+		if field.Setter {
+			dst = dstPrefix + "Set" + field.Destination + "()"
+		}
+		src := "-"
+		if field.Source != "" {
+			src = srcPrefix + field.Source
+			if field.Kind == SourceGetter {
+				src += "()"
+			}
+		}
+		details := string(field.Kind)
+		if field.Configured {
+			details += ", configured"
+		}
+		fmt.Fprintf(w, "  %s\t<- %s\t(%s)\n", dst, src, details)
+
+		if len(field.Fields) > 0 {
+			suffix := "."
+			if field.Collection {
+				suffix = "[*]."
+			}
+			writeFieldPlans(w, dstPrefix+field.Destination+suffix, src+suffix, field.Fields)
+		}
+	}
+}
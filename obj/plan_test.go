@@ -0,0 +1,122 @@
+package obj
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPlanItemDTO struct {
+	SKU string
+}
+
+type testPlanItem struct {
+	SKU   string
+	Price int
+}
+
+type testPlanOrderDTO struct {
+	ID       int
+	Customer string
+	Items    []testPlanItemDTO
+	Secret   string
+}
+
+func (o testPlanOrderDTO) GetTotal() int {
+	return 0
+}
+
+type testPlanOrder struct {
+	ID        int
+	Buyer     string
+	Total     int
+	Items     []*testPlanItem
+	Secret    string
+	Reference string
+	status    string
+}
+
+func (o *testPlanOrder) SetStatus(status string) {
+	o.status = status
+}
+
+type testPlanNick struct {
+	SKU  string
+	nick string
+}
+
+func (n *testPlanNick) SetNick(nick string) {
+	n.nick = nick
+}
+
+func TestExplain(t *testing.T) {
+	mapper := NewMapper()
+	err := ConfigureFieldMaps[testPlanOrderDTO, testPlanOrder](mapper,
+		FieldMapConfig{Destination: "Buyer", Source: "Customer"},
+		FieldMapConfig{Destination: "Secret", Ignore: true},
+		FieldMapConfig{Destination: "Reference", Source: "ID", GetDestinationValue: func(source any) (any, error) {
+			return "", nil
+		}},
+	)
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+
+	plan, err := Explain[testPlanOrderDTO, *testPlanOrder](mapper)
+
+	assert.Nil(t, err, "Explain returned an error")
+	assert.Equal(t, []FieldPlan{
+		{Destination: "ID", Source: "ID", Kind: SourceField},
+		{Destination: "Buyer", Source: "Customer", Kind: SourceField, Configured: true},
+		{Destination: "Total", Source: "GetTotal", Kind: SourceGetter},
+		{Destination: "Items", Source: "Items", Kind: SourceField, Collection: true, Fields: []FieldPlan{
+			{Destination: "SKU", Source: "SKU", Kind: SourceField},
+			{Destination: "Price", Kind: SourceNone},
+		}},
+		{Destination: "Secret", Kind: SourceIgnored, Configured: true},
+		{Destination: "Reference", Source: "ID", Kind: SourceConverter, Configured: true},
+		{Destination: "Status", Kind: SourceMissing, Setter: true},
+	}, plan.Fields)
+	assert.Equal(t, `obj.testPlanOrderDTO -> obj.testPlanOrder
+  ID              <- ID            (field)
+  Buyer           <- Customer      (field, configured)
+  Total           <- GetTotal()    (getter)
+  Items           <- Items         (field)
+  Items[*].SKU    <- Items[*].SKU  (field)
+  Items[*].Price  <- -             (none)
+  Secret          <- -             (ignored, configured)
+  Reference       <- ID            (converter, configured)
+  SetStatus()     <- -             (missing)
+`, plan.String())
+}
+
+func TestPlanSetterWithoutSource(t *testing.T) {
+	mapper := NewMapper()
+	plan, err := Explain[testPlanItemDTO, testPlanNick](mapper)
+
+	assert.Nil(t, err, "Explain returned an error")
+	assert.Equal(t, []FieldPlan{
+		{Destination: "SKU", Source: "SKU", Kind: SourceField},
+		{Destination: "Nick", Kind: SourceMissing, Setter: true},
+	}, plan.Fields)
+
+	// the plan reports what Map does
+	dst := testPlanNick{}
+	assert.ErrorIs(t, mapper.Map(testPlanItemDTO{SKU: "a"}, &dst), ErrFieldNotFound)
+}
+
+func TestPlanRecursiveType(t *testing.T) {
+	type Node struct {
+		Value    int
+		Children []Node
+	}
+
+	plan, err := Explain[Node, Node](NewMapper())
+
+	assert.Nil(t, err, "Explain returned an error")
+	assert.Equal(t, []FieldPlan{
+		{Destination: "Value", Source: "Value", Kind: SourceField},
+		{Destination: "Children", Source: "Children", Kind: SourceField, Collection: true},
+	}, plan.Fields)
+
+	_, err = Explain[int, Node](NewMapper())
+	assert.EqualError(t, err, "source and destination types must be structs")
+}
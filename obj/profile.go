@@ -0,0 +1,223 @@
+package obj
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownType returned when a mapping profile refers to a type that isn't registered.
+var ErrUnknownType error = fmt.Errorf("unknown type")
+
+// ErrUnknownConverter returned when a mapping profile refers to a converter that isn't registered.
+var ErrUnknownConverter error = fmt.Errorf("unknown converter")
+
+// TypeRegistry resolves the names of the types and converters used in mapping profiles.
+type TypeRegistry struct {
+	types      map[string]reflect.Type
+	converters map[string]func(source any) (any, error)
+}
+
+// NewTypeRegistry creates a new instance of TypeRegistry
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		types:      make(map[string]reflect.Type),
+		converters: make(map[string]func(source any) (any, error)),
+	}
+}
+
+// RegisterType registers T, which must be a struct, under name. If name is
+// empty, the name of T without its package is used, e.g. User.
+func RegisterType[T any](registry *TypeRegistry, name string) error {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("T must be a struct")
+	}
+	if name == "" {
+		name = t.Name()
+	}
+	if existing, ok := registry.types[name]; ok && existing != t {
+		return fmt.Errorf("type name %q is already registered for %s", name, existing)
+	}
+	registry.types[name] = t
+	return nil
+}
+
+// RegisterConverter registers a converter that profiles can refer to by name.
+// It is used as the [FieldMapConfig.GetDestinationValue] of the field.
+func (r *TypeRegistry) RegisterConverter(name string, converter func(source any) (any, error)) error {
+	if name == "" || converter == nil {
+		return fmt.Errorf("converter name and function must be provided")
+	}
+	if _, ok := r.converters[name]; ok {
+		return fmt.Errorf("converter %q is already registered", name)
+	}
+	r.converters[name] = converter
+	return nil
+}
+
+// MappingProfiles is the content of a file loaded with [LoadProfiles].
+type MappingProfiles struct {
+	Profiles []MappingProfile `json:"profiles" yaml:"profiles"`
+}
+
+// MappingProfile declares how the fields of a pair of types are mapped, like [ConfigureFieldMaps].
+type MappingProfile struct {
+	// Source is the registered name of the source type
+	Source string `json:"source" yaml:"source"`
+
+	// Destination is the registered name of the destination type
+	Destination string `json:"destination" yaml:"destination"`
+
+	Fields []FieldProfile `json:"fields" yaml:"fields"`
+}
+
+// FieldProfile declares how a destination field is mapped, like [FieldMapConfig].
+type FieldProfile struct {
+	// Destination is the name of the destination field or setter
+	Destination string `json:"destination" yaml:"destination"`
+
+	// Source is the name of the source field or getter. Defaults to Destination.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+
+	// Converter is the registered name of the converter of the value
+	Converter string `json:"converter,omitempty" yaml:"converter,omitempty"`
+
+	// Ignore leaves the destination field untouched when mapping.
+	Ignore bool `json:"ignore,omitempty" yaml:"ignore,omitempty"`
+}
+
+// LoadProfiles reads mapping profiles in YAML or JSON from r and configures
+// mapper with them. Type and converter names are resolved with registry.
+// Nothing is configured if any of the profiles is invalid, and the error
+// lists all the problems found.
+// Sample profiles:
+//
+//	profiles:
+//	  - source: UserDTO
+//	    destination: User
+//	    fields:
+//	      - destination: FullName
+//	        source: Name
+//	      - destination: CreatedAt
+//	        converter: parseTime
+//	      - destination: PasswordHash
+//	        ignore: true
+//
+// Sample usage:
+//
+//	registry := obj.NewTypeRegistry()
+//	err := obj.RegisterType[UserDTO](registry, "")
+//	...
+//	err = obj.LoadProfiles(mapper, registry, file)
+func LoadProfiles(mapper *Mapper, registry *TypeRegistry, r io.Reader) error {
+	profiles := MappingProfiles{}
+	// YAML is a superset of JSON, so both are decoded by the YAML decoder
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	err := decoder.Decode(&profiles)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decoding mapping profiles: %w", err)
+	}
+	return ApplyProfiles(mapper, registry, profiles)
+}
+
+// ApplyProfiles configures mapper with profiles, like [LoadProfiles].
+func ApplyProfiles(mapper *Mapper, registry *TypeRegistry, profiles MappingProfiles) error {
+	type resolved struct {
+		source      reflect.Type
+		destination reflect.Type
+		fieldMaps   []FieldMapConfig
+	}
+	var errs []error
+	var all []resolved
+	for i, profile := range profiles.Profiles {
+		sourceType, sourceErr := registry.resolveType(profile.Source)
+		destinationType, destinationErr := registry.resolveType(profile.Destination)
+		if sourceErr != nil || destinationErr != nil {
+			errs = append(errs, profileError(i, profile, errors.Join(sourceErr, destinationErr)))
+			continue
+		}
+
+		fieldMaps := make([]FieldMapConfig, 0, len(profile.Fields))
+		for _, field := range profile.Fields {
+			fieldMap, err := registry.resolveField(sourceType, destinationType, field)
+			if err != nil {
+				errs = append(errs, profileError(i, profile, err))
+				continue
+			}
+			fieldMaps = append(fieldMaps, fieldMap)
+		}
+		all = append(all, resolved{sourceType, destinationType, fieldMaps})
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, r := range all {
+		err := configureFieldMaps(mapper, r.source, r.destination, r.fieldMaps...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func profileError(i int, profile MappingProfile, err error) error {
+	return fmt.Errorf("profile %d (%s to %s): %w", i, profile.Source, profile.Destination, err)
+}
+
+func (r *TypeRegistry) resolveType(name string) (reflect.Type, error) {
+	if t, ok := r.types[name]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownType, name)
+}
+
+// resolveField returns the FieldMapConfig of field after checking that the fields and converter exist.
+func (r *TypeRegistry) resolveField(sourceType reflect.Type, destinationType reflect.Type, field FieldProfile) (FieldMapConfig, error) {
+	if field.Destination == "" {
+		return FieldMapConfig{}, fmt.Errorf("destination field names must be provided")
+	}
+	if !hasFieldOrMethod(destinationType, field.Destination, "Set") {
+		return FieldMapConfig{}, fmt.Errorf("%s.%s: %w", destinationType.Name(), field.Destination, ErrFieldNotFound)
+	}
+	fieldMap := FieldMapConfig{
+		Source:      field.Source,
+		Destination: field.Destination,
+		Ignore:      field.Ignore,
+	}
+	if field.Ignore {
+		return fieldMap, nil
+	}
+
+	sourceName := field.Source
+	if sourceName == "" {
+		sourceName = field.Destination
+	}
+	if !hasFieldOrMethod(sourceType, sourceName, "Get") {
+		return FieldMapConfig{}, fmt.Errorf("%s.%s: %w", sourceType.Name(), sourceName, ErrFieldNotFound)
+	}
+	if field.Converter != "" {
+		converter, ok := r.converters[field.Converter]
+		if !ok {
+			return FieldMapConfig{}, fmt.Errorf("%s: %w: %q", field.Destination, ErrUnknownConverter, field.Converter)
+		}
+		fieldMap.GetDestinationValue = converter
+	}
+	return fieldMap, nil
+}
+
+// hasFieldOrMethod reports whether the struct t has an exported field named
+// name or a method named prefix+name, e.g. SetName.
+func hasFieldOrMethod(t reflect.Type, name string, prefix string) bool {
+	if field, ok := t.FieldByName(name); ok && field.IsExported() {
+		return true
+	}
+	_, ok := reflect.PointerTo(t).MethodByName(prefix + name)
+	return ok
+}
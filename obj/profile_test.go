@@ -0,0 +1,129 @@
+package obj
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testProfileUserDTO struct {
+	ID        int
+	Name      string
+	CreatedAt string
+	Password  string
+}
+
+type testProfileUser struct {
+	ID        int
+	FullName  string
+	CreatedAt time.Time
+	Password  string
+}
+
+func newTestProfileRegistry(t *testing.T) *TypeRegistry {
+	registry := NewTypeRegistry()
+	assert.Nil(t, RegisterType[testProfileUserDTO](registry, "UserDTO"))
+	assert.Nil(t, RegisterType[testProfileUser](registry, "User"))
+	assert.Nil(t, registry.RegisterConverter("parseDate", func(source any) (any, error) {
+		return time.Parse(time.DateOnly, source.(string))
+	}))
+	return registry
+}
+
+func TestLoadProfiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles string
+	}{
+		{
+			name: "YAML",
+			profiles: `
+profiles:
+  - source: UserDTO
+    destination: User
+    fields:
+      - destination: FullName
+        source: Name
+      - destination: CreatedAt
+        converter: parseDate
+      - destination: Password
+        ignore: true
+`,
+		},
+		{
+			name: "JSON",
+			profiles: `{"profiles": [{"source": "UserDTO", "destination": "User", "fields": [
+				{"destination": "FullName", "source": "Name"},
+				{"destination": "CreatedAt", "converter": "parseDate"},
+				{"destination": "Password", "ignore": true}
+			]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapper := NewMapper()
+			err := LoadProfiles(mapper, newTestProfileRegistry(t), strings.NewReader(test.profiles))
+			assert.Nil(t, err, "LoadProfiles returned an error")
+
+			user := testProfileUser{}
+			err = mapper.Map(testProfileUserDTO{ID: 1, Name: "John", CreatedAt: "2024-05-01", Password: "secret"}, &user)
+			assert.Nil(t, err, "Map returned an error")
+			assert.Equal(t, testProfileUser{
+				ID:        1,
+				FullName:  "John",
+				CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			}, user)
+		})
+	}
+}
+
+func TestLoadProfilesErrors(t *testing.T) {
+	profiles := `
+profiles:
+  - source: UserDTO
+    destination: User
+    fields:
+      - destination: FullName
+        source: Name
+  - source: UserDTO
+    destination: Account
+  - source: UserDTO
+    destination: User
+    fields:
+      - destination: Nickname
+      - destination: FullName
+        source: Surname
+      - destination: CreatedAt
+        converter: parseTime
+`
+	mapper := NewMapper()
+	err := LoadProfiles(mapper, newTestProfileRegistry(t), strings.NewReader(profiles))
+
+	assert.ErrorIs(t, err, ErrUnknownType)
+	assert.ErrorIs(t, err, ErrFieldNotFound)
+	assert.ErrorIs(t, err, ErrUnknownConverter)
+	assert.EqualError(t, err, `profile 1 (UserDTO to Account): unknown type: "Account"
+profile 2 (UserDTO to User): testProfileUser.Nickname: field not found
+profile 2 (UserDTO to User): testProfileUserDTO.Surname: field not found
+profile 2 (UserDTO to User): CreatedAt: unknown converter: "parseTime"`)
+	assert.Empty(t, mapper.cfg.fieldMaps, "invalid profiles must not configure the mapper")
+
+	err = LoadProfiles(mapper, newTestProfileRegistry(t), strings.NewReader("profiles:\n  - source: UserDTO\n    target: User\n"))
+	assert.ErrorContains(t, err, "field target not found")
+}
+
+func TestRegisterType(t *testing.T) {
+	registry := NewTypeRegistry()
+	assert.Nil(t, RegisterType[testProfileUser](registry, ""))
+	assert.Equal(t, map[string]reflect.Type{"testProfileUser": reflect.TypeOf(testProfileUser{})}, registry.types)
+
+	err := RegisterType[testProfileUserDTO](registry, "testProfileUser")
+	assert.EqualError(t, err, `type name "testProfileUser" is already registered for obj.testProfileUser`)
+
+	err = RegisterType[int](registry, "")
+	assert.EqualError(t, err, "T must be a struct")
+}
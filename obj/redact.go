@@ -0,0 +1,155 @@
+package obj
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RedactionMode indicates how the value of a sensitive field is masked.
+type RedactionMode string
+
+const (
+	// RedactFull replaces strings with a fixed mask and other values with their zero value.
+	RedactFull RedactionMode = "full"
+
+	// RedactLast4 masks all but the last 4 characters of strings, e.g. ************1111.
+	RedactLast4 RedactionMode = "last4"
+
+	// RedactHash replaces strings with their SHA-256 hash so that they can still be correlated.
+	RedactHash RedactionMode = "hash"
+)
+
+// RedactedMask replaces the value of strings redacted with [RedactFull].
+const RedactedMask = "********"
+
+// ConfigureSensitiveFields marks fields of T as sensitive. When the mapper is
+// created with [MapperOptions.Redact], the values of sensitive fields are
+// masked according to mode, whether T is the source or the destination.
+// Fields can also be marked with a tag, e.g. `sensitive:"last4"`. An empty tag
+// value uses [RedactFull].
+func ConfigureSensitiveFields[T any](mapper *Mapper, mode RedactionMode, fields ...string) error {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("T must be a struct")
+	}
+	if !mode.valid() {
+		return fmt.Errorf("unknown redaction mode %q", mode)
+	}
+
+	sensitive := mapper.cfg.sensitive[t]
+	if sensitive == nil {
+		sensitive = make(map[string]RedactionMode)
+	}
+	for _, field := range fields {
+		if _, ok := t.FieldByName(field); !ok {
+			if _, ok := reflect.PointerTo(t).MethodByName("Set" + field); !ok {
+				return fmt.Errorf("%s: %w", field, ErrFieldNotFound)
+			}
+		}
+		sensitive[field] = mode
+	}
+	mapper.cfg.sensitive[t] = sensitive
+	return nil
+}
+
+func (mode RedactionMode) valid() bool {
+	return mode == RedactFull || mode == RedactLast4 || mode == RedactHash
+}
+
+// sensitiveMode returns the redaction mode if the source or destination field is sensitive.
+func (m *Mapper) sensitiveMode(srcType reflect.Type, srcField string, dstType reflect.Type, dstField string) (RedactionMode, bool) {
+	if mode, ok := m.sensitiveField(dstType, dstField); ok {
+		return mode, true
+	}
+	if mode, ok := m.sensitiveField(srcType, srcField); ok {
+		return mode, true
+	}
+	if field, ok := dstType.FieldByName(dstField); ok {
+		if mode, ok := sensitiveTag(field); ok {
+			return mode, true
+		}
+	}
+	if srcType.Kind() == reflect.Struct {
+		if field, ok := srcType.FieldByName(srcField); ok {
+			if mode, ok := sensitiveTag(field); ok {
+				return mode, true
+			}
+		}
+	}
+	return "", false
+}
+
+func sensitiveTag(field reflect.StructField) (RedactionMode, bool) {
+	tag, ok := field.Tag.Lookup("sensitive")
+	if !ok || tag == "-" || tag == "false" {
+		return "", false
+	}
+	mode := RedactionMode(tag)
+	if !mode.valid() {
+		mode = RedactFull
+	}
+	return mode, true
+}
+
+// redactValue masks v and everything it contains, including the values held by
+// interfaces: strings according to mode and other values with their zero
+// value. A copy is masked and then assigned to
+// v, since what v points to may be shared with the source, e.g. when a
+// converter returns the source value.
+func redactValue(v reflect.Value, mode RedactionMode) {
+	redacted := deepCopy(v)
+	defer v.Set(redacted)
+	_ = Walk(redacted.Addr().Interface(), VisitorFunc(func(node *WalkNode) error {
+		// values are replaced so that Walk stores back map values
+		switch node.Value.Kind() {
+		case reflect.String:
+			redacted := reflect.New(node.Type()).Elem()
+			redacted.SetString(redactString(node.Value.String(), mode))
+			return ignoreNotAddressable(node.Replace(redacted.Interface()))
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+			return ignoreNotAddressable(node.Replace(reflect.Zero(node.Type()).Interface()))
+		case reflect.Struct:
+			if isTextValue(node.Value.Type()) {
+				err := ignoreNotAddressable(node.Replace(reflect.Zero(node.Type()).Interface()))
+				if err != nil {
+					return err
+				}
+				return ErrSkipChildren
+			}
+		}
+		return nil
+	}))
+}
+
+// ignoreNotAddressable ignores the error of replacing values that can't be
+// set, e.g. values in interfaces holding pointers.
+func ignoreNotAddressable(err error) error {
+	if err == ErrNotAddresable {
+		return nil
+	}
+	return err
+}
+
+func redactString(s string, mode RedactionMode) string {
+	if s == "" {
+		return s
+	}
+	switch mode {
+	case RedactLast4:
+		runes := []rune(s)
+		if len(runes) <= 4 {
+			return strings.Repeat("*", len(runes))
+		}
+		return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+	case RedactHash:
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:])
+	}
+	return RedactedMask
+}
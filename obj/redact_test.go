@@ -0,0 +1,153 @@
+package obj
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRedactCard struct {
+	Number string `sensitive:"last4"`
+	CVV    int    `sensitive:""`
+	Holder string
+}
+
+type testRedactPayment struct {
+	ID      int
+	Token   string
+	Card    *testRedactCard
+	Cards   []testRedactCard
+	Secrets map[string]string `sensitive:"hash"`
+	Wallet  map[string]testRedactCard
+}
+
+type testRedactPaymentDTO struct {
+	ID      int
+	Token   string
+	Card    *testRedactCard
+	Cards   []testRedactCard
+	Secrets map[string]string
+	Wallet  map[string]testRedactCard
+}
+
+func testRedactSource() testRedactPayment {
+	return testRedactPayment{
+		ID:      1,
+		Token:   "tok_123",
+		Card:    &testRedactCard{Number: "4111111111111111", CVV: 123, Holder: "John"},
+		Cards:   []testRedactCard{{Number: "5500", CVV: 1, Holder: "Jane"}},
+		Secrets: map[string]string{"api": "key"},
+		Wallet:  map[string]testRedactCard{"main": {Number: "340000000000009"}},
+	}
+}
+
+func TestMapRedact(t *testing.T) {
+	mapper := NewMapperWithOptions(MapperOptions{Redact: true})
+	err := ConfigureSensitiveFields[testRedactPayment](mapper, RedactFull, "Token")
+	assert.Nil(t, err, "ConfigureSensitiveFields returned an error")
+
+	src := testRedactSource()
+	dst := testRedactPaymentDTO{}
+	err = mapper.Map(src, &dst)
+
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, testRedactPaymentDTO{
+		ID:      1,
+		Token:   RedactedMask,
+		Card:    &testRedactCard{Number: "************1111", Holder: "John"},
+		Cards:   []testRedactCard{{Number: "****", Holder: "Jane"}},
+		Secrets: map[string]string{"api": "2c70e12b7a0646f92279f427c7b38e7334d8e5389cff167a1dc30e73f826b683"},
+		Wallet:  map[string]testRedactCard{"main": {Number: "***********0009"}},
+	}, dst)
+	assert.Equal(t, testRedactSource(), src, "source changed")
+}
+
+func TestMapRedactConverterSharingSource(t *testing.T) {
+	mapper := NewMapperWithOptions(MapperOptions{Redact: true})
+	err := ConfigureFieldMaps[testRedactPayment, testRedactPaymentDTO](mapper, FieldMapConfig{
+		Destination: "Card",
+		GetDestinationValue: func(source any) (any, error) {
+			return source, nil
+		},
+	})
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+	err = ConfigureSensitiveFields[testRedactPayment](mapper, RedactLast4, "Card")
+	assert.Nil(t, err, "ConfigureSensitiveFields returned an error")
+
+	src := testRedactSource()
+	dst := testRedactPaymentDTO{}
+	err = mapper.Map(src, &dst)
+
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, &testRedactCard{Number: "************1111", Holder: "****"}, dst.Card)
+	assert.Equal(t, testRedactSource(), src, "source changed")
+}
+
+func TestMapRedactDisabled(t *testing.T) {
+	mapper := NewMapper()
+	err := ConfigureSensitiveFields[testRedactPayment](mapper, RedactFull, "Token")
+	assert.Nil(t, err, "ConfigureSensitiveFields returned an error")
+
+	dst := testRedactPaymentDTO{}
+	err = mapper.Map(testRedactSource(), &dst)
+
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, "tok_123", dst.Token)
+	assert.Equal(t, "4111111111111111", dst.Card.Number)
+}
+
+func TestMapRedactSetter(t *testing.T) {
+	mapper := NewMapperWithOptions(MapperOptions{Redact: true})
+	err := ConfigureSensitiveFields[testUserWithSetter](mapper, RedactLast4, "Name")
+	assert.Nil(t, err, "ConfigureSensitiveFields returned an error")
+
+	user := testUserWithSetter{}
+	err = mapper.Map(testUserDTO{ID: 1, withGetterName: "Johnson"}, &user)
+
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, "*******nson", user.withSetterName)
+}
+
+func TestCloneRedact(t *testing.T) {
+	mapper := NewMapperWithOptions(MapperOptions{Redact: true})
+	src := testRedactSource()
+
+	clone, err := Clone(mapper, &src)
+
+	assert.Nil(t, err, "Clone returned an error")
+	assert.NotSame(t, src.Card, clone.Card)
+	assert.Equal(t, "************1111", clone.Card.Number)
+	assert.Equal(t, "tok_123", clone.Token)
+	assert.Equal(t, "4111111111111111", src.Card.Number)
+}
+
+func TestCloneRedactInterfaces(t *testing.T) {
+	type Event struct {
+		Secret any            `sensitive:"last4"`
+		Extra  map[string]any `sensitive:""`
+		Items  []any          `sensitive:""`
+	}
+	mapper := NewMapperWithOptions(MapperOptions{Redact: true})
+	src := Event{
+		Secret: "4111111111111111",
+		Extra:  map[string]any{"pw": "hunter2", "nested": map[string]any{"pin": 1234.0}},
+		Items:  []any{"a", 1.5, true},
+	}
+
+	clone, err := Clone(mapper, src)
+
+	assert.Nil(t, err, "Clone returned an error")
+	assert.Equal(t, Event{
+		Secret: "************1111",
+		Extra:  map[string]any{"pw": RedactedMask, "nested": map[string]any{"pin": 0.0}},
+		Items:  []any{RedactedMask, 0.0, false},
+	}, clone)
+	assert.Equal(t, "hunter2", src.Extra["pw"], "source changed")
+}
+
+func TestConfigureSensitiveFieldsErrors(t *testing.T) {
+	mapper := NewMapper()
+	assert.ErrorIs(t, ConfigureSensitiveFields[testRedactPayment](mapper, RedactFull, "Unknown"), ErrFieldNotFound)
+	assert.Error(t, ConfigureSensitiveFields[testRedactPayment](mapper, "mask", "Token"))
+	assert.Error(t, ConfigureSensitiveFields[string](mapper, RedactFull))
+}
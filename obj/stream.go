@@ -0,0 +1,212 @@
+package obj
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/bryan-t/goeasy/async"
+)
+
+// StreamOptions contains settings of MapStreamWithOptions and MapIterator.
+type StreamOptions struct {
+	// Workers is the number of values mapped at the same time. Defaults to 1.
+	Workers int
+
+	// Unordered allows mapped values to be sent as soon as they are mapped
+	// instead of in the order they are received.
+	Unordered bool
+
+	// Buffer is the capacity of the channel of mapped values.
+	Buffer int
+}
+
+// streamResult is a value mapped by MapStream.
+type streamResult[D any] struct {
+	task  *mapTask
+	value D
+	err   error
+}
+
+// MapStream maps the values received from in to values of D sent to the
+// returned channel, in the order they are received. Mapping stops when in is
+// closed, at the first error, which is sent to the error channel, or when ctx
+// is cancelled, in which case the error of ctx is sent. Both channels are
+// closed when mapping stops. Callers that stop receiving from the returned
+// channel before it is closed must cancel ctx, otherwise the goroutines
+// sending to it and the values being mapped are leaked.
+// Sample usage:
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	out, errs := obj.MapStream[Record, Row](ctx, mapper, records)
+//	for row := range out {
+//		...
+//	}
+//	if err := <-errs; err != nil {
+//		...
+//	}
+func MapStream[S any, D any](ctx context.Context, mapper *Mapper, in <-chan S) (<-chan D, <-chan error) {
+	return MapStreamWithOptions[S, D](ctx, mapper, in, StreamOptions{})
+}
+
+// MapStreamWithOptions is like MapStream but allows values to be mapped
+// concurrently. As with MapStream, ctx must be cancelled if the returned
+// channel isn't drained.
+func MapStreamWithOptions[S any, D any](ctx context.Context, mapper *Mapper, in <-chan S, options StreamOptions) (<-chan D, <-chan error) {
+	workers := max(options.Workers, 1)
+	out := make(chan D, max(options.Buffer, 0))
+	errs := make(chan error, 1)
+	mapCtx, cancel := context.WithCancel(ctx)
+
+	// results are sent in the order the values are received, or as they are
+	// mapped if unordered
+	results := make(chan *streamResult[D], workers)
+	slots := make(chan struct{}, workers)
+	var running sync.WaitGroup
+	go func() {
+		defer func() {
+			running.Wait()
+			close(results)
+		}()
+		for {
+			var value S
+			var ok bool
+			select {
+			case value, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-mapCtx.Done():
+				return
+			}
+			select {
+			case slots <- struct{}{}:
+			case <-mapCtx.Done():
+				return
+			}
+
+			result := &streamResult[D]{}
+			result.task = newMapTask(mapCtx, func() {
+				defer running.Done()
+				state := newMapState()
+				state.ctx = mapCtx
+				result.err = mapper.mapValue(state, reflect.ValueOf(&value).Elem(), reflect.ValueOf(&result.value).Elem())
+				<-slots
+				if options.Unordered {
+					select {
+					case results <- result:
+					case <-mapCtx.Done():
+					}
+				}
+			})
+			running.Add(1)
+			async.Start(result.task)
+			if !options.Unordered {
+				select {
+				case results <- result:
+				case <-mapCtx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer close(errs)
+		defer close(out)
+		defer cancel()
+		for result := range results {
+			result.task.Wait()
+			if result.err != nil {
+				errs <- result.err
+				cancel()
+				return
+			}
+			select {
+			case out <- result.value:
+			case <-mapCtx.Done():
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			errs <- err
+		}
+	}()
+	return out, errs
+}
+
+// StreamIterator iterates over values mapped by MapIterator.
+// Sample usage:
+//
+//	it := obj.MapIterator[Record, Row](ctx, mapper, reader.Next, obj.StreamOptions{})
+//	defer it.Close()
+//	for it.Next() {
+//		row := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type StreamIterator[D any] struct {
+	out    <-chan D
+	errs   <-chan error
+	cancel context.CancelFunc
+	value  D
+	err    error
+}
+
+// MapIterator maps the values returned by next until it returns false, like
+// MapStreamWithOptions. next is called from a single goroutine.
+func MapIterator[S any, D any](ctx context.Context, mapper *Mapper, next func() (S, bool), options StreamOptions) *StreamIterator[D] {
+	ctx, cancel := context.WithCancel(ctx)
+	in := make(chan S)
+	go func() {
+		defer close(in)
+		for {
+			value, ok := next()
+			if !ok {
+				return
+			}
+			select {
+			case in <- value:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	out, errs := MapStreamWithOptions[S, D](ctx, mapper, in, options)
+	return &StreamIterator[D]{out: out, errs: errs, cancel: cancel}
+}
+
+// Next advances to the next mapped value. It returns false when there are no
+// more values or mapping failed, see Err.
+func (it *StreamIterator[D]) Next() bool {
+	value, ok := <-it.out
+	if !ok {
+		var zero D
+		it.value = zero
+		if err, ok := <-it.errs; ok && it.err == nil {
+			it.err = err
+		}
+		return false
+	}
+	it.value = value
+	return true
+}
+
+// Value returns the current mapped value.
+func (it *StreamIterator[D]) Value() D {
+	return it.value
+}
+
+// Err returns the error that stopped the mapping, if any.
+func (it *StreamIterator[D]) Err() error {
+	return it.err
+}
+
+// Close stops the mapping. It must be called if the iterator isn't exhausted.
+func (it *StreamIterator[D]) Close() {
+	it.cancel()
+	for range it.out {
+	}
+}
@@ -0,0 +1,146 @@
+package obj
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStreamRecord struct {
+	ID int
+}
+
+type testStreamRow struct {
+	ID string
+}
+
+func testStreamMapper(t *testing.T, failAt int) *Mapper {
+	mapper := NewMapper()
+	err := ConfigureFieldMaps[testStreamRecord, testStreamRow](mapper, FieldMapConfig{
+		Destination: "ID",
+		GetDestinationValue: func(source any) (any, error) {
+			id := source.(int)
+			if id == failAt {
+				return nil, fmt.Errorf("invalid id %d", id)
+			}
+			// later records are mapped faster to shuffle unordered results
+			time.Sleep(time.Duration(10-id%10) * 100 * time.Microsecond)
+			return fmt.Sprint(id), nil
+		},
+	})
+	assert.Nil(t, err, "ConfigureFieldMaps returned an error")
+	return mapper
+}
+
+func testStreamInput(n int) <-chan testStreamRecord {
+	in := make(chan testStreamRecord)
+	go func() {
+		defer close(in)
+		for i := 0; i < n; i++ {
+			in <- testStreamRecord{ID: i}
+		}
+	}()
+	return in
+}
+
+func TestMapStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		options StreamOptions
+	}{
+		{
+			name: "Sequential",
+		},
+		{
+			name:    "Concurrent",
+			options: StreamOptions{Workers: 4, Buffer: 2},
+		},
+		{
+			name:    "Unordered",
+			options: StreamOptions{Workers: 4, Unordered: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, errs := MapStreamWithOptions[testStreamRecord, testStreamRow](context.Background(), testStreamMapper(t, -1), testStreamInput(50), test.options)
+
+			var ids []string
+			for row := range out {
+				ids = append(ids, row.ID)
+			}
+			assert.Nil(t, <-errs, "MapStream returned an error")
+
+			expected := make([]string, 50)
+			for i := range expected {
+				expected[i] = fmt.Sprint(i)
+			}
+			if test.options.Unordered {
+				assert.ElementsMatch(t, expected, ids)
+			} else {
+				assert.Equal(t, expected, ids)
+			}
+		})
+	}
+}
+
+func TestMapStreamError(t *testing.T) {
+	out, errs := MapStreamWithOptions[testStreamRecord, testStreamRow](context.Background(), testStreamMapper(t, 5), testStreamInput(50), StreamOptions{Workers: 3})
+
+	var ids []string
+	for row := range out {
+		ids = append(ids, row.ID)
+	}
+	assert.EqualError(t, <-errs, "invalid id 5")
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, ids)
+}
+
+func TestMapStreamCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan testStreamRecord)
+	out, errs := MapStream[testStreamRecord, testStreamRow](ctx, testStreamMapper(t, -1), in)
+
+	in <- testStreamRecord{ID: 1}
+	assert.Equal(t, testStreamRow{ID: "1"}, <-out)
+	cancel()
+
+	for range out {
+	}
+	assert.ErrorIs(t, <-errs, context.Canceled)
+}
+
+func TestMapIterator(t *testing.T) {
+	i := 0
+	next := func() (testStreamRecord, bool) {
+		i++
+		return testStreamRecord{ID: i}, i <= 3
+	}
+	it := MapIterator[testStreamRecord, testStreamRow](context.Background(), testStreamMapper(t, -1), next, StreamOptions{Workers: 2})
+	defer it.Close()
+
+	var rows []testStreamRow
+	for it.Next() {
+		rows = append(rows, it.Value())
+	}
+	assert.Nil(t, it.Err(), "iterator returned an error")
+	assert.Equal(t, []testStreamRow{{"1"}, {"2"}, {"3"}}, rows)
+	assert.False(t, it.Next())
+}
+
+func TestMapIteratorError(t *testing.T) {
+	i := 0
+	next := func() (testStreamRecord, bool) {
+		i++
+		return testStreamRecord{ID: i}, true
+	}
+	it := MapIterator[testStreamRecord, testStreamRow](context.Background(), testStreamMapper(t, 2), next, StreamOptions{})
+	defer it.Close()
+
+	assert.True(t, it.Next())
+	assert.Equal(t, testStreamRow{"1"}, it.Value())
+	assert.False(t, it.Next())
+	assert.EqualError(t, it.Err(), "invalid id 2")
+}
@@ -0,0 +1,123 @@
+package obj
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// mapText maps between strings or []byte and types implementing
+// encoding.TextMarshaler, encoding.TextUnmarshaler or fmt.Stringer. With
+// [MapperOptions.JSONBytes], structs, maps and slices are also mapped to and
+// from []byte through JSON. It returns false if none of these apply.
+func (m *Mapper) mapText(src reflect.Value, dst reflect.Value) (bool, error) {
+	if src.Type() == dst.Type() {
+		if src.Kind() == reflect.Struct && isTextValue(src.Type()) {
+			// values like time.Time are copied as is since their fields are not exported
+			dst.Set(src)
+			return true, nil
+		}
+		return false, nil
+	}
+
+	// string and []byte kinds are only converted to the other kind, e.g. net.IP from a string
+	srcIsText := src.Kind() == reflect.String || isBytes(src.Type())
+	dstIsText := dst.Kind() == reflect.String || isBytes(dst.Type())
+	sameTextKind := srcIsText && dstIsText && (src.Kind() == reflect.String) == (dst.Kind() == reflect.String)
+
+	if srcIsText && !sameTextKind {
+		text := textOf(src)
+		if dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshalerType) {
+			err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(text)
+			if err != nil {
+				return true, fmt.Errorf("%w: %q as %s: %v", ErrInvalidValue, text, dst.Type(), err)
+			}
+			return true, nil
+		}
+		if m.cfg.options.JSONBytes && isBytes(src.Type()) && !dstIsText && isJSONValue(dst.Type()) {
+			newVal := reflect.New(dst.Type())
+			err := json.Unmarshal(text, newVal.Interface())
+			if err != nil {
+				return true, fmt.Errorf("%w: %s: %v", ErrInvalidValue, dst.Type(), err)
+			}
+			dst.Set(newVal.Elem())
+			return true, nil
+		}
+	}
+
+	if dstIsText && !sameTextKind {
+		if marshaler, ok := interfaceOf(src, textMarshalerType); ok {
+			text, err := marshaler.(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return true, err
+			}
+			setText(dst, text)
+			return true, nil
+		}
+		if m.cfg.options.JSONBytes && isBytes(dst.Type()) && !srcIsText && isJSONValue(src.Type()) {
+			if !src.CanInterface() {
+				return false, nil
+			}
+			text, err := json.Marshal(src.Interface())
+			if err != nil {
+				return true, err
+			}
+			setText(dst, text)
+			return true, nil
+		}
+		if stringer, ok := interfaceOf(src, stringerType); ok && dst.Kind() == reflect.String && !srcIsText {
+			dst.SetString(stringer.(fmt.Stringer).String())
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// isJSONValue reports whether t is encoded as a JSON object or array.
+func isJSONValue(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+func textOf(v reflect.Value) []byte {
+	if v.Kind() == reflect.String {
+		return []byte(v.String())
+	}
+	return v.Bytes()
+}
+
+func setText(dst reflect.Value, text []byte) {
+	if dst.Kind() == reflect.String {
+		dst.SetString(string(text))
+		return
+	}
+	dst.SetBytes(append([]byte(nil), text...))
+}
+
+// interfaceOf returns v as an implementation of iface, using a pointer to a
+// copy of v for methods with pointer receivers.
+func interfaceOf(v reflect.Value, iface reflect.Type) (any, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+	if v.Type().Implements(iface) {
+		return v.Interface(), true
+	}
+	if reflect.PointerTo(v.Type()).Implements(iface) {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		return ptr.Interface(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,124 @@
+package obj
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTextID [4]byte
+
+func (id testTextID) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(id[:])), nil
+}
+
+func (id *testTextID) UnmarshalText(text []byte) error {
+	_, err := hex.Decode(id[:], text)
+	return err
+}
+
+type testTextEvent struct {
+	ID        testTextID
+	IP        net.IP
+	CreatedAt time.Time
+	Payload   []byte
+}
+
+type testTextEventDTO struct {
+	ID        string
+	IP        string
+	CreatedAt string
+	Payload   []byte
+}
+
+func TestMapTextMarshaler(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := testTextEvent{
+		ID:        testTextID{0xde, 0xad, 0xbe, 0xef},
+		IP:        net.ParseIP("10.0.0.1"),
+		CreatedAt: createdAt,
+		Payload:   []byte("raw"),
+	}
+
+	mapper := NewMapper()
+	dto := testTextEventDTO{}
+	err := mapper.Map(event, &dto)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, testTextEventDTO{
+		ID:        "deadbeef",
+		IP:        "10.0.0.1",
+		CreatedAt: "2024-01-02T03:04:05Z",
+		Payload:   []byte("raw"),
+	}, dto)
+
+	mapped := testTextEvent{}
+	err = mapper.Map(dto, &mapped)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, event, mapped)
+
+	dto.CreatedAt = "yesterday"
+	err = mapper.Map(dto, &mapped)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestMapTextSameType(t *testing.T) {
+	type Audit struct {
+		CreatedAt time.Time
+		UpdatedAt *time.Time
+		internal  int
+	}
+	now := time.Now()
+	src := Audit{CreatedAt: now, UpdatedAt: &now, internal: 1}
+	dst := Audit{}
+
+	err := NewMapper().Map(src, &dst)
+
+	assert.Nil(t, err, "Map returned an error")
+	assert.True(t, now.Equal(dst.CreatedAt))
+	assert.True(t, now.Equal(*dst.UpdatedAt))
+	assert.Equal(t, 0, dst.internal)
+}
+
+func TestMapJSONBytes(t *testing.T) {
+	type Item struct {
+		Name string `json:"name"`
+	}
+	type Order struct {
+		Item  Item
+		Items []Item
+		Attrs map[string]int
+	}
+	type OrderRecord struct {
+		Item  json.RawMessage
+		Items []byte
+		Attrs []byte
+	}
+
+	order := Order{Item: Item{"a"}, Items: []Item{{"b"}}, Attrs: map[string]int{"x": 1}}
+	mapper := NewMapperWithOptions(MapperOptions{JSONBytes: true})
+
+	record := OrderRecord{}
+	err := mapper.Map(order, &record)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, OrderRecord{
+		Item:  json.RawMessage(`{"name":"a"}`),
+		Items: []byte(`[{"name":"b"}]`),
+		Attrs: []byte(`{"x":1}`),
+	}, record)
+
+	mapped := Order{}
+	err = mapper.Map(record, &mapped)
+	assert.Nil(t, err, "Map returned an error")
+	assert.Equal(t, order, mapped)
+
+	record.Item = json.RawMessage(`{"name":1}`)
+	err = mapper.Map(record, &mapped)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	err = NewMapper().Map(order, &record)
+	assert.Equal(t, ErrMismatchType, err)
+}
@@ -0,0 +1,245 @@
+package obj
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ErrSkipChildren can be returned by a [Visitor] to skip the children of the
+// current node. It is not returned by Walk.
+var ErrSkipChildren error = fmt.Errorf("skip children")
+
+// WalkNode is a value visited by [Walk].
+type WalkNode struct {
+	// Path of the value, e.g. Servers[2].Timeout or Labels[env]. The root value has an empty path.
+	Path string
+
+	// Value being visited
+	Value reflect.Value
+
+	// Field contains the metadata of the struct field holding the value, nil
+	// if the value is not held by a struct field.
+	Field *reflect.StructField
+
+	// Depth is the number of structs, slices, arrays and maps between the root and the value.
+	Depth int
+
+	walker *walker
+}
+
+// Type returns the type of the value being visited.
+func (n *WalkNode) Type() reflect.Type {
+	return n.Value.Type()
+}
+
+// Replace replaces the value being visited. Values are converted like
+// [Set] does, with the mapper passed to [WithWalkMapper]. The children of the new value are visited afterwards.
+// ErrNotAddresable is returned if the value passed to [Walk] isn't a pointer.
+func (n *WalkNode) Replace(value any) error {
+	if n.walker == nil || !n.walker.writable || !n.Value.CanSet() {
+		return ErrNotAddresable
+	}
+	err := assignValue(n.walker.mapper, n.Value, value)
+	if err != nil {
+		return err
+	}
+	n.walker.replaced++
+	return nil
+}
+
+// Visitor is called by [Walk] for every value.
+type Visitor interface {
+	// Visit is called for every value. Returning [ErrSkipChildren] skips the
+	// children of the value, any other error stops the walk.
+	Visit(node *WalkNode) error
+}
+
+// VisitorFunc allows a function to be used as a [Visitor].
+type VisitorFunc func(node *WalkNode) error
+
+// Visit calls f(node)
+func (f VisitorFunc) Visit(node *WalkNode) error {
+	return f(node)
+}
+
+// WalkOption customizes the behavior of Walk.
+type WalkOption func(*walker)
+
+// WithWalkMapper converts the values passed to [WalkNode.Replace] with the
+// conversion rules of mapper, e.g. the names of its registered enums.
+// Defaults to a new mapper.
+func WithWalkMapper(mapper *Mapper) WalkOption {
+	return func(w *walker) {
+		w.mapper = mapper
+	}
+}
+
+// Walk traverses v depth-first and calls visitor for every value: v itself,
+// the exported fields of structs, the elements of slices and arrays and the
+// values of maps (in order of their keys). Pointers and interfaces are
+// followed, and values already visited through a pointer, slice or map are not
+// traversed again. The value held by an interface is visited as a node of its
+// own after the interface, with the same path and a nil Field. Values can only be replaced with [WalkNode.Replace] if v is
+// a pointer. Map values and values in interfaces are visited as copies that are
+// only stored back if something within them was replaced, so a visitor that
+// doesn't replace anything never writes to v.
+// Sample usage:
+//
+//	err := obj.Walk(&user, obj.VisitorFunc(func(node *obj.WalkNode) error {
+//		if node.Field != nil && node.Field.Tag.Get("secret") != "" {
+//			return node.Replace("***")
+//		}
+//		return nil
+//	}))
+func Walk(v any, visitor Visitor, opts ...WalkOption) error {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return nil
+	}
+	w := walker{
+		visitor: visitor,
+		visited: make(map[walkVisitKey]bool),
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	if w.mapper == nil {
+		w.mapper = NewMapper()
+	}
+
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		// the root is the value pointed to so that it can be replaced
+		w.seen(value, 0)
+		w.writable = true
+		value = value.Elem()
+	}
+	return w.walk(&WalkNode{Value: value})
+}
+
+type walkVisitKey struct {
+	pointer uintptr
+	length  int
+	typ     reflect.Type
+}
+
+type walker struct {
+	visitor Visitor
+	visited map[walkVisitKey]bool
+
+	// mapper converts the values passed to Replace
+	mapper *Mapper
+
+	// writable indicates that the root was passed as a pointer
+	writable bool
+
+	// replaced counts the calls of Replace, to know if copies must be stored back
+	replaced int
+}
+
+func (w *walker) walk(node *WalkNode) error {
+	node.walker = w
+	err := w.visitor.Visit(node)
+	if err == ErrSkipChildren {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return w.walkChildren(node, node.Value)
+}
+
+// walkChildren walks the children of v which is node's value or the value it points to.
+func (w *walker) walkChildren(node *WalkNode, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || w.seen(v, 0) {
+			return nil
+		}
+		return w.walkChildren(node, v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		elem := v.Elem()
+		if !w.writable || !v.CanSet() {
+			return w.walk(&WalkNode{Path: node.Path, Value: elem, Depth: node.Depth})
+		}
+		// values in interfaces are not addressable, walk a copy and store it back
+		elemCopy := reflect.New(elem.Type()).Elem()
+		elemCopy.Set(elem)
+		replaced := w.replaced
+		err := w.walk(&WalkNode{Path: node.Path, Value: elemCopy, Depth: node.Depth})
+		if err != nil {
+			return err
+		}
+		if w.replaced != replaced {
+			v.Set(elemCopy)
+		}
+		return nil
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			err := w.walk(&WalkNode{
+				Path:  joinFieldPath(node.Path, field.Name),
+				Value: v.Field(i),
+				Field: &field,
+				Depth: node.Depth + 1,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.IsNil() || w.seen(v, v.Len())) {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			err := w.walk(&WalkNode{
+				Path:  joinIndexPath(node.Path, i),
+				Value: v.Index(i),
+				Depth: node.Depth + 1,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() || w.seen(v, 0) {
+			return nil
+		}
+		keys := v.MapKeys()
+		sortValues(keys)
+		for _, key := range keys {
+			// map values are not addressable, walk a copy and store it back
+			val := reflect.New(v.Type().Elem()).Elem()
+			val.Set(v.MapIndex(key))
+			replaced := w.replaced
+			err := w.walk(&WalkNode{
+				Path:  joinKeyPath(node.Path, key),
+				Value: val,
+				Depth: node.Depth + 1,
+			})
+			if err != nil {
+				return err
+			}
+			if w.writable && w.replaced != replaced {
+				v.SetMapIndex(key, val)
+			}
+		}
+	}
+	return nil
+}
+
+// seen marks the value referenced by v as visited and reports whether it
+// already was.
+func (w *walker) seen(v reflect.Value, length int) bool {
+	key := walkVisitKey{pointer: v.Pointer(), length: length, typ: v.Type()}
+	if w.visited[key] {
+		return true
+	}
+	w.visited[key] = true
+	return false
+}
@@ -0,0 +1,246 @@
+package obj
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testWalkCard struct {
+	Number string `secret:"true"`
+	Expiry string
+}
+
+type testWalkUser struct {
+	Name     string
+	Token    string `secret:"true"`
+	Card     *testWalkCard
+	Cards    []testWalkCard
+	Meta     map[string]testWalkCard
+	Extra    any
+	internal string
+}
+
+func TestWalk(t *testing.T) {
+	user := testWalkUser{
+		Name:  "John",
+		Card:  &testWalkCard{Number: "4111"},
+		Cards: []testWalkCard{{Number: "5500"}},
+		Meta:  map[string]testWalkCard{"b": {}, "a": {}},
+		Extra: 1,
+	}
+
+	var visited []string
+	err := Walk(user, VisitorFunc(func(node *WalkNode) error {
+		visited = append(visited, fmt.Sprintf("%s:%s:%d", node.Path, node.Type(), node.Depth))
+		return nil
+	}))
+
+	assert.Nil(t, err, "Walk returned an error")
+	assert.Equal(t, []string{
+		":obj.testWalkUser:0",
+		"Name:string:1",
+		"Token:string:1",
+		"Card:*obj.testWalkCard:1",
+		"Card.Number:string:2",
+		"Card.Expiry:string:2",
+		"Cards:[]obj.testWalkCard:1",
+		"Cards[0]:obj.testWalkCard:2",
+		"Cards[0].Number:string:3",
+		"Cards[0].Expiry:string:3",
+		"Meta:map[string]obj.testWalkCard:1",
+		"Meta[a]:obj.testWalkCard:2",
+		"Meta[a].Number:string:3",
+		"Meta[a].Expiry:string:3",
+		"Meta[b]:obj.testWalkCard:2",
+		"Meta[b].Number:string:3",
+		"Meta[b].Expiry:string:3",
+		"Extra:interface {}:1",
+		"Extra:int:1",
+	}, visited)
+}
+
+func TestWalkReplace(t *testing.T) {
+	user := testWalkUser{
+		Name:  "John",
+		Token: "abc",
+		Card:  &testWalkCard{Number: "4111"},
+		Cards: []testWalkCard{{Number: "5500"}},
+		Meta:  map[string]testWalkCard{"a": {Number: "3400"}},
+		Extra: testWalkCard{Number: "6011"},
+	}
+
+	err := Walk(&user, VisitorFunc(func(node *WalkNode) error {
+		if node.Field != nil && node.Field.Tag.Get("secret") != "" {
+			return node.Replace("***")
+		}
+		return nil
+	}))
+
+	assert.Nil(t, err, "Walk returned an error")
+	assert.Equal(t, testWalkUser{
+		Name:  "John",
+		Token: "***",
+		Card:  &testWalkCard{Number: "***"},
+		Cards: []testWalkCard{{Number: "***"}},
+		Meta:  map[string]testWalkCard{"a": {Number: "***"}},
+		Extra: testWalkCard{Number: "***"},
+	}, user)
+}
+
+func TestWalkInterfaceValues(t *testing.T) {
+	type Walked struct {
+		Data  any
+		Tags  map[string]any
+		Items []any
+	}
+	walked := Walked{Data: "hello", Tags: map[string]any{"a": "b"}, Items: []any{"c", 1}}
+
+	var visited []string
+	err := Walk(&walked, VisitorFunc(func(node *WalkNode) error {
+		visited = append(visited, fmt.Sprintf("%s:%s", node.Path, node.Type()))
+		if node.Value.Kind() == reflect.String {
+			return node.Replace(strings.ToUpper(node.Value.String()))
+		}
+		return nil
+	}))
+
+	assert.Nil(t, err, "Walk returned an error")
+	assert.Equal(t, []string{
+		":obj.Walked",
+		"Data:interface {}",
+		"Data:string",
+		"Tags:map[string]interface {}",
+		"Tags[a]:interface {}",
+		"Tags[a]:string",
+		"Items:[]interface {}",
+		"Items[0]:interface {}",
+		"Items[0]:string",
+		"Items[1]:interface {}",
+		"Items[1]:int",
+	}, visited)
+	assert.Equal(t, Walked{Data: "HELLO", Tags: map[string]any{"a": "B"}, Items: []any{"C", 1}}, walked)
+}
+
+func TestWalkReplaceWithMapper(t *testing.T) {
+	type Account struct {
+		Status testEnumStatus
+	}
+	mapper := NewMapper()
+	err := RegisterEnum(mapper, testEnumStatusNames)
+	assert.Nil(t, err, "RegisterEnum returned an error")
+
+	account := Account{}
+	err = Walk(&account, VisitorFunc(func(node *WalkNode) error {
+		if node.Path == "Status" {
+			return node.Replace("SUSPENDED")
+		}
+		return nil
+	}), WithWalkMapper(mapper))
+
+	assert.Nil(t, err, "Walk returned an error")
+	assert.Equal(t, Account{Status: testEnumStatusSuspended}, account)
+}
+
+func TestWalkReplaceNotAddressable(t *testing.T) {
+	err := Walk(testWalkUser{Token: "abc"}, VisitorFunc(func(node *WalkNode) error {
+		if node.Path == "Token" {
+			return node.Replace("***")
+		}
+		return nil
+	}))
+	assert.Equal(t, ErrNotAddresable, err)
+}
+
+func TestWalkReplaceNotPointer(t *testing.T) {
+	meta := map[string]testWalkCard{"a": {Number: "3400"}}
+
+	err := Walk(meta, VisitorFunc(func(node *WalkNode) error {
+		if node.Field != nil && node.Field.Tag.Get("secret") != "" {
+			return node.Replace("***")
+		}
+		return nil
+	}))
+
+	assert.Equal(t, ErrNotAddresable, err)
+	assert.Equal(t, map[string]testWalkCard{"a": {Number: "3400"}}, meta, "map changed")
+}
+
+func TestWalkReadOnly(t *testing.T) {
+	user := testWalkUser{Meta: map[string]testWalkCard{"a": {Number: "3400"}}, Extra: testWalkCard{}}
+
+	// a visitor that doesn't replace anything must not write to the maps, so
+	// concurrent readers don't race with it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = user.Meta["a"]
+		}
+	}()
+	err := Walk(&user, VisitorFunc(func(node *WalkNode) error {
+		return nil
+	}))
+	<-done
+
+	assert.Nil(t, err, "Walk returned an error")
+	assert.Equal(t, map[string]testWalkCard{"a": {Number: "3400"}}, user.Meta)
+}
+
+func TestWalkSkipChildren(t *testing.T) {
+	user := testWalkUser{Card: &testWalkCard{}, Cards: []testWalkCard{{}}}
+
+	var visited []string
+	err := Walk(&user, VisitorFunc(func(node *WalkNode) error {
+		visited = append(visited, node.Path)
+		if node.Type().Kind() == reflect.Slice || node.Type().Kind() == reflect.Pointer {
+			return ErrSkipChildren
+		}
+		return nil
+	}))
+
+	assert.Nil(t, err, "Walk returned an error")
+	for _, path := range visited {
+		assert.False(t, strings.HasPrefix(path, "Card."), "children of Card visited: %s", path)
+		assert.False(t, strings.HasPrefix(path, "Cards["), "children of Cards visited: %s", path)
+	}
+	assert.Contains(t, visited, "Card")
+	assert.Contains(t, visited, "Cards")
+}
+
+func TestWalkError(t *testing.T) {
+	expected := fmt.Errorf("test error")
+	count := 0
+	err := Walk(testWalkUser{}, VisitorFunc(func(node *WalkNode) error {
+		count++
+		if node.Path == "Token" {
+			return expected
+		}
+		return nil
+	}))
+
+	assert.Equal(t, expected, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestWalkCycle(t *testing.T) {
+	type Node struct {
+		Value int
+		Next  *Node
+	}
+	a := &Node{Value: 1}
+	b := &Node{Value: 2, Next: a}
+	a.Next = b
+
+	var visited []string
+	err := Walk(a, VisitorFunc(func(node *WalkNode) error {
+		visited = append(visited, node.Path)
+		return nil
+	}))
+
+	assert.Nil(t, err, "Walk returned an error")
+	assert.Equal(t, []string{"", "Value", "Next", "Next.Value", "Next.Next"}, visited)
+}